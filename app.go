@@ -0,0 +1,141 @@
+package main
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// openMsg puts a view like a form or a confirmation on top of the board.
+type openMsg struct {
+	view tea.Model
+	// card is set for views acting on the selected card, which stays
+	// highlighted behind them
+	card bool
+}
+
+// closeMsg closes the view on top without a result.
+type closeMsg struct{}
+
+// submitMsg closes the view on top and hands its result, like a taskSavedMsg,
+// to the board.
+type submitMsg struct {
+	result tea.Msg
+}
+
+func openView(view tea.Model) tea.Cmd {
+	return func() tea.Msg { return openMsg{view: view} }
+}
+
+func openCardView(view tea.Model) tea.Cmd {
+	return func() tea.Msg { return openMsg{view: view, card: true} }
+}
+
+func closeView() tea.Msg {
+	return closeMsg{}
+}
+
+func submit(result tea.Msg) tea.Cmd {
+	return func() tea.Msg { return submitMsg{result} }
+}
+
+// layer is a view on the stack.
+type layer struct {
+	view tea.Model
+	card bool
+}
+
+// App is the root model. It keeps a stack of views on top of the board, the
+// keys go to the view on top, the results of commands to the board and the
+// view on top. The view on top is drawn over the dimmed board.
+type App struct {
+	board  *Board
+	views  []layer
+	width  int
+	height int
+}
+
+func NewApp(b *Board) *App {
+	return &App{board: b}
+}
+
+func (a *App) Init() tea.Cmd {
+	return a.board.Init()
+}
+
+// top returns the view on top of the board, nil if there is none.
+func (a *App) top() tea.Model {
+	if len(a.views) == 0 {
+		return nil
+	}
+	return a.views[len(a.views)-1].view
+}
+
+func (a *App) pop() {
+	if len(a.views) > 0 {
+		a.views = a.views[:len(a.views)-1]
+	}
+}
+
+func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case openMsg:
+		a.views = append(a.views, layer{view: msg.view, card: msg.card})
+		// the release of the button goes to the view, so the card isn't dragged
+		// anymore
+		a.board.dragged = nil
+		cmd := msg.view.Init()
+		// the view sizes itself to the screen like after a resize
+		if a.width > 0 {
+			cmd = tea.Batch(cmd, a.updateTop(tea.WindowSizeMsg{Width: a.width, Height: a.height}))
+		}
+		return a, cmd
+	case closeMsg:
+		a.pop()
+		return a, nil
+	case submitMsg:
+		a.pop()
+		return a, a.updateBoard(msg.result)
+	case tea.WindowSizeMsg:
+		a.width, a.height = msg.Width, msg.Height
+		cmds := []tea.Cmd{a.updateBoard(msg)}
+		for i := range a.views {
+			var cmd tea.Cmd
+			a.views[i].view, cmd = a.views[i].view.Update(msg)
+			cmds = append(cmds, cmd)
+		}
+		return a, tea.Batch(cmds...)
+	case tea.KeyMsg, tea.MouseMsg:
+		if a.top() != nil {
+			return a, a.updateTop(msg)
+		}
+		return a, a.updateBoard(msg)
+	}
+	return a, tea.Batch(a.updateBoard(msg), a.updateTop(msg))
+}
+
+func (a *App) updateBoard(msg tea.Msg) tea.Cmd {
+	_, cmd := a.board.Update(msg)
+	return cmd
+}
+
+func (a *App) updateTop(msg tea.Msg) tea.Cmd {
+	if a.top() == nil {
+		return nil
+	}
+	var cmd tea.Cmd
+	top := &a.views[len(a.views)-1]
+	top.view, cmd = top.view.Update(msg)
+	return cmd
+}
+
+func (a *App) View() string {
+	board := a.board.View()
+	if len(a.views) == 0 || a.board.quitting {
+		return board
+	}
+	top := a.views[len(a.views)-1]
+	var source *zone
+	if z, ok := a.board.selectedCard(); ok && top.card {
+		source = &z
+	}
+	return overlay(board, top.view.View(), a.width, a.height, source)
+}
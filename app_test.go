@@ -0,0 +1,73 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// viewStub is a view that shows its name and records the messages it gets.
+type viewStub struct {
+	name string
+	msgs *[]tea.Msg
+}
+
+func (v viewStub) Init() tea.Cmd {
+	return nil
+}
+
+func (v viewStub) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	*v.msgs = append(*v.msgs, msg)
+	return v, nil
+}
+
+func (v viewStub) View() string {
+	return v.name
+}
+
+func TestAppViewStack(t *testing.T) {
+	b, err := loadBoard(newFakeBackend(), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	a := NewApp(b)
+	var first, second []tea.Msg
+
+	a.Update(openMsg{view: viewStub{"first", &first}})
+	a.Update(openMsg{view: viewStub{"second", &second}})
+	if view := a.View(); view != "second" {
+		t.Errorf("expected the view on top, got %q", view)
+	}
+
+	a.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}})
+	a.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+	if len(first) != 1 || len(second) != 2 {
+		t.Errorf("expected keys only for the view on top and the size for all, got %v and %v", first, second)
+	}
+
+	a.Update(closeMsg{})
+	if view := a.View(); !strings.Contains(view, "first") || !strings.Contains(view, "To Do") {
+		t.Errorf("expected the view below over the board after closing, got %q", view)
+	}
+	a.Update(closeMsg{})
+	if view := a.View(); !strings.Contains(view, "To Do") {
+		t.Errorf("expected the board after closing all views, got %q", view)
+	}
+}
+
+func TestAppSeparateBoards(t *testing.T) {
+	fake := newFakeBackend(uiTasks()...)
+	first, err := loadBoard(fake, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fake.add(map[string]any{"description": "Call mom"})
+	second, err := loadBoard(fake, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(first.tasks()) != 4 || len(second.tasks()) != 5 {
+		t.Errorf("expected the boards to keep their own tasks, got %d and %d", len(first.tasks()), len(second.tasks()))
+	}
+}
@@ -0,0 +1,24 @@
+package main
+
+import (
+	"bytes"
+	"os/exec"
+)
+
+// Backend runs the commands of taskwarrior and timewarrior. The board and the
+// subcommands get it passed, so the tests can use a fake instead.
+type Backend interface {
+	// Run runs the command and returns its standard output.
+	Run(cmd []string) (string, error)
+}
+
+// execBackend runs the commands as processes.
+type execBackend struct{}
+
+func (execBackend) Run(cmdStr []string) (string, error) {
+	cmd := exec.Command(cmdStr[0], cmdStr[1:]...)
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	err := cmd.Run()
+	return out.String(), err
+}
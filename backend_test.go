@@ -0,0 +1,154 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// fakeBackend answers the taskwarrior commands of the board from tasks in
+// memory and records every command. Timewarrior isn't installed.
+type fakeBackend struct {
+	mu    sync.Mutex
+	tasks []map[string]any
+	cmds  []string
+}
+
+// newFakeBackend starts with the tasks in the format of `task export`, ids and
+// uuids are added when they are missing.
+func newFakeBackend(tasks ...map[string]any) *fakeBackend {
+	f := &fakeBackend{}
+	for _, t := range tasks {
+		f.add(t)
+	}
+	return f
+}
+
+func (f *fakeBackend) add(t map[string]any) int {
+	id := len(f.tasks) + 1
+	if _, ok := t["id"]; !ok {
+		t["id"] = id
+	}
+	if _, ok := t["uuid"]; !ok {
+		t["uuid"] = fmt.Sprintf("00000000-0000-4000-8000-%012d", id)
+	}
+	if _, ok := t["status"]; !ok {
+		t["status"] = "pending"
+	}
+	if _, ok := t["urgency"]; !ok {
+		t["urgency"] = float64(id)
+	}
+	f.tasks = append(f.tasks, t)
+	return id
+}
+
+// commands returns the recorded commands that change tasks, reading ones
+// like export are left out.
+func (f *fakeBackend) commands() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var cmds []string
+	for _, c := range f.cmds {
+		if !strings.HasSuffix(c, " export") && !strings.HasSuffix(c, " _urgency") && !strings.HasPrefix(c, "task _") {
+			cmds = append(cmds, c)
+		}
+	}
+	return cmds
+}
+
+// find returns the tasks matching a filter of ids like `1,2` or a uuid.
+func (f *fakeBackend) find(filter string) []map[string]any {
+	var found []map[string]any
+	for _, ref := range strings.Split(filter, ",") {
+		for _, t := range f.tasks {
+			if fmt.Sprint(t["id"]) == ref || t["uuid"] == ref {
+				found = append(found, t)
+			}
+		}
+	}
+	return found
+}
+
+func (f *fakeBackend) Run(cmd []string) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.cmds = append(f.cmds, strings.Join(cmd, " "))
+	if cmd[0] != "task" {
+		return "", fmt.Errorf("%s is not installed", cmd[0])
+	}
+	args := slices.DeleteFunc(slices.Clone(cmd[1:]), func(arg string) bool { return strings.HasPrefix(arg, "rc.") })
+
+	switch {
+	case len(args) == 0:
+		return "", errors.New("no command")
+	case args[len(args)-1] == "export":
+		tasks := f.tasks
+		for _, filter := range args[:len(args)-1] {
+			filter = strings.Trim(filter, "()")
+			if _, err := strconv.Atoi(strings.Split(filter, ",")[0]); err == nil || len(filter) == 36 {
+				tasks = f.find(filter)
+			}
+		}
+		out, err := json.Marshal(tasks)
+		return string(out), err
+	case args[0] == "add":
+		task := map[string]any{}
+		var words, tags []string
+		for _, arg := range args[1:] {
+			attr, value, ok := strings.Cut(arg, ":")
+			switch {
+			case strings.HasPrefix(arg, "+"):
+				tags = append(tags, arg[1:])
+			case ok && (attr == "project" || attr == "priority"):
+				task[attr] = value
+			case !ok:
+				words = append(words, arg)
+			}
+		}
+		task["description"] = strings.Join(words, " ")
+		if len(tags) > 0 {
+			task["tags"] = tags
+		}
+		id := f.add(task)
+		return fmt.Sprintf("Created task %d.\n", id), nil
+	case len(args) < 2:
+		return "", nil
+	}
+
+	tasks := f.find(args[0])
+	if len(tasks) == 0 {
+		return "", fmt.Errorf("no task %s", args[0])
+	}
+	for _, t := range tasks {
+		switch args[1] {
+		case "_urgency":
+			return fmt.Sprintf("task %s urgency %.1f\n", args[0], t["urgency"]), nil
+		case "start":
+			t["start"] = "20240301T120000Z"
+		case "stop":
+			delete(t, "start")
+		case "done":
+			t["status"], t["end"] = "completed", "20240301T120000Z"
+			delete(t, "start")
+		case "delete":
+			t["status"], t["end"] = "deleted", "20240301T120000Z"
+		case "modify":
+			for _, arg := range args[2:] {
+				attr, value, _ := strings.Cut(arg, ":")
+				if attr != "depends" {
+					continue
+				}
+				if value == "" {
+					delete(t, "depends")
+				} else {
+					t["depends"] = []any{value}
+				}
+			}
+		}
+	}
+	return "", nil
+}
@@ -47,12 +47,17 @@ func (d blockItemDelegate) Render(w io.Writer, m list.Model, index int, listItem
 	fmt.Fprint(w, fn(str))
 }
 
+// blockMsg is the result of the block form.
+type blockMsg struct {
+	blocker Task
+	blocked []Task
+}
+
 type Block struct {
 	todoTaskList  list.Model
 	selectedTasks map[string]bool
 	help          help.Model
 	todoTasks     []Task
-	column        column
 	blocking      Task
 	index         int
 }
@@ -61,7 +66,7 @@ func (b Block) Init() tea.Cmd {
 	return nil
 }
 
-func NewBlockForm(t Task, todos []list.Item, height, width int) *Block {
+func NewBlockForm(t Task, todos []list.Item, width, height int) *Block {
 	var filteredTodos []list.Item
 	var filteredTasks []Task
 
@@ -75,8 +80,8 @@ func NewBlockForm(t Task, todos []list.Item, height, width int) *Block {
 	}
 
 	l := list.New([]list.Item{}, blockItemDelegate{}, width, height)
-
 	l.Title = fmt.Sprintf("'%s' blocks?", t.description)
+	l.Styles.Title = styles.DefaultListTitleStyle
 	l.SetFilteringEnabled(false)
 	l.SetShowStatusBar(false)
 	l.SetShowHelp(false)
@@ -87,7 +92,6 @@ func NewBlockForm(t Task, todos []list.Item, height, width int) *Block {
 		todoTaskList:  l,
 		todoTasks:     filteredTasks,
 		selectedTasks: map[string]bool{},
-		column:        column{},
 		index:         0,
 		help:          help.New(),
 	}
@@ -100,12 +104,11 @@ func (b Block) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
-		b.column.setSize(msg.Width, msg.Height)
-		b.column.list.SetSize(msg.Width/margin, msg.Height-8)
+		b.todoTaskList.SetHeight(msg.Height / 2)
 	case tea.KeyMsg:
 		switch {
 		case key.Matches(msg, keys.Enter):
-			return board.Update(b)
+			return b, submit(blockMsg{blocker: b.blocking, blocked: b.GetSelectedTasks()})
 		case key.Matches(msg, keys.Space):
 			selected := b.todoTaskList.SelectedItem().(Task)
 			if b.selectedTasks[selected.uuid] {
@@ -115,7 +118,7 @@ func (b Block) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			return b, nil
 		case key.Matches(msg, keys.Back):
-			return board.Update(nil)
+			return b, closeView
 		case key.Matches(msg, keys.Quit):
 			return b, tea.Quit
 		}
@@ -131,11 +134,7 @@ func (b Block) View() string {
 	b.todoTaskList.SetDelegate(blockItemDelegate{selectedTasks: b.selectedTasks})
 	content := b.todoTaskList.View()
 
-	return lipgloss.JoinVertical(
-		lipgloss.Left,
-		b.column.getStyle().Render(content),
-		helpView,
-	)
+	return styles.FormStyle.Render(lipgloss.JoinVertical(lipgloss.Left, content, helpView))
 }
 
 func (b Block) GetSelectedTasks() []Task {
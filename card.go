@@ -0,0 +1,256 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/DerTimonius/twkb/styles"
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/reflow/truncate"
+)
+
+const (
+	ellipsis = "…"
+	// maxUrgency is the urgency at which the urgency bar is full.
+	maxUrgency     = 20.0
+	urgencyBarSize = 10
+)
+
+// cardFields are the fields that can be shown on a card.
+var cardFields = []string{"project", "tags", "due", "priority", "age", "tracked", "urgency"}
+
+// CardConfig configures which fields are shown on the cards, in which order
+// and in which colours.
+type CardConfig struct {
+	Fields  []string          `json:"fields"`
+	Colors  map[string]string `json:"colors"`
+	Compact bool              `json:"compact"`
+}
+
+func defaultCardConfig() CardConfig {
+	return CardConfig{Fields: []string{"project", "tags", "due", "age", "tracked", "urgency"}}
+}
+
+// cardDelegate renders the tasks of a column as cards. Expanded cards show the
+// title, a line of chips and an urgency bar, compact cards fit everything on
+// a single line.
+type cardDelegate struct {
+	fields  []string
+	styles  cardStyles
+	compact bool
+	// terms of the board search that get highlighted
+	terms []string
+}
+
+type cardStyles struct {
+	title    lipgloss.Style
+	project  lipgloss.Style
+	tag      lipgloss.Style
+	due      lipgloss.Style
+	priority lipgloss.Style
+	age      lipgloss.Style
+	tracked  lipgloss.Style
+	urgency  lipgloss.Style
+}
+
+func newCardDelegate(c CardConfig, compact bool) cardDelegate {
+	var fields []string
+	for _, f := range c.Fields {
+		if slices.Contains(cardFields, f) {
+			fields = append(fields, f)
+		}
+	}
+
+	s := cardStyles{
+		title:    styles.CardTitleStyle,
+		project:  styles.ProjectBadgeStyle,
+		tag:      styles.TagPillStyle,
+		due:      styles.DueChipStyle,
+		priority: styles.PriorityChipStyle,
+		age:      styles.AgeChipStyle,
+		tracked:  styles.TrackedChipStyle,
+		urgency:  styles.UrgencyBarStyle,
+	}
+	// badges have a coloured background, everything else a coloured text
+	for name, color := range c.Colors {
+		color := lipgloss.Color(color)
+		switch name {
+		case "title":
+			s.title = s.title.Copy().Foreground(color)
+		case "project":
+			s.project = s.project.Copy().Background(color)
+		case "tags":
+			s.tag = s.tag.Copy().Foreground(color)
+		case "due":
+			s.due = s.due.Copy().Foreground(color)
+		case "priority":
+			s.priority = s.priority.Copy().Foreground(color)
+		case "age":
+			s.age = s.age.Copy().Foreground(color)
+		case "tracked":
+			s.tracked = s.tracked.Copy().Foreground(color)
+		case "urgency":
+			s.urgency = s.urgency.Copy().Foreground(color)
+		}
+	}
+
+	return cardDelegate{fields: fields, styles: s, compact: compact}
+}
+
+// highlight returns the delegate highlighting the search terms.
+func (d cardDelegate) highlight(terms []string) cardDelegate {
+	d.terms = terms
+	return d
+}
+
+func (d cardDelegate) Height() int {
+	if d.compact {
+		return 1
+	}
+	if slices.Contains(d.fields, "urgency") {
+		return 3
+	}
+	return 2
+}
+
+func (d cardDelegate) Spacing() int {
+	if d.compact {
+		return 0
+	}
+	return 1
+}
+
+func (d cardDelegate) Update(_ tea.Msg, _ *list.Model) tea.Cmd { return nil }
+
+func (d cardDelegate) Render(w io.Writer, m list.Model, index int, item list.Item) {
+	t, ok := item.(Task)
+	if !ok || m.Width() <= 0 {
+		return
+	}
+	now := time.Now()
+	selected := index == m.Index()
+
+	lineStyle := styles.CardStyle
+	if selected {
+		lineStyle = styles.SelectedCardStyle
+	}
+	width := m.Width() - lineStyle.GetHorizontalFrameSize()
+
+	titleStyle := d.styles.title
+	switch t.dueState(now) {
+	case overdue:
+		titleStyle = titleStyle.Copy().Foreground(lipgloss.Color(styles.Red))
+	case dueToday:
+		titleStyle = titleStyle.Copy().Foreground(lipgloss.Color(styles.Peach))
+	default:
+		if selected {
+			titleStyle = titleStyle.Copy().Foreground(lipgloss.Color(styles.Pink))
+		}
+	}
+
+	title := truncate.StringWithTail(t.Title(), uint(width), ellipsis)
+	if len(d.terms) > 0 {
+		matched := titleStyle.Copy().Underline(true)
+		title = lipgloss.StyleRunes(title, matchIndexes(title, d.terms), matched, titleStyle)
+	} else {
+		title = titleStyle.Render(title)
+	}
+
+	chips := d.chips(t, now)
+	if d.compact {
+		line := strings.Join(append([]string{title}, chips...), " ")
+		fmt.Fprint(w, markCard(index, lineStyle.Render(truncate.StringWithTail(line, uint(width), ellipsis))))
+		return
+	}
+
+	lines := []string{title, truncate.StringWithTail(strings.Join(chips, " "), uint(width), ellipsis)}
+	if slices.Contains(d.fields, "urgency") {
+		lines = append(lines, d.urgencyBar(t.urgency))
+	}
+	for i := range lines {
+		lines[i] = lineStyle.Render(lines[i])
+	}
+	fmt.Fprint(w, markCard(index, strings.Join(lines, "\n")))
+}
+
+// chips renders the configured fields of the task in their order. The urgency
+// is only part of the chips on compact cards, expanded cards show a bar.
+func (d cardDelegate) chips(t Task, now time.Time) []string {
+	var chips []string
+	for _, f := range d.fields {
+		switch f {
+		case "project":
+			if t.project != "" {
+				chips = append(chips, d.matched(d.styles.project, t.project).Render(t.project))
+			}
+		case "tags":
+			for _, tag := range t.tags {
+				chips = append(chips, d.matched(d.styles.tag, tag).Render("#"+tag))
+			}
+		case "due":
+			if t.due.IsZero() {
+				continue
+			}
+			style := d.styles.due
+			switch t.dueState(now) {
+			case overdue:
+				style = style.Copy().Foreground(lipgloss.Color(styles.Red))
+			case dueToday:
+				style = style.Copy().Foreground(lipgloss.Color(styles.Peach))
+			}
+			chips = append(chips, style.Render("⏰ "+formatDue(t.due, now, config.DueFormat)))
+		case "priority":
+			if t.priority != "" {
+				chips = append(chips, d.styles.priority.Render("!"+t.priority))
+			}
+		case "age":
+			// the age in the done column says little
+			age := t.age(now)
+			if age == 0 || t.status == done || t.status == never {
+				continue
+			}
+			style := d.styles.age
+			if t.idle(now) {
+				style = styles.IdleChipStyle
+			}
+			chips = append(chips, style.Render("⌛ "+humanizeDuration(age)))
+		case "tracked":
+			if config.Timewarrior && t.TrackedTotal(now) > 0 {
+				chips = append(chips, d.styles.tracked.Render(fmt.Sprintf("⏱ %s (today %s)", formatDuration(t.TrackedTotal(now)), formatDuration(t.TrackedToday(now)))))
+			}
+		case "urgency":
+			if d.compact {
+				chips = append(chips, d.styles.urgency.Render(fmt.Sprintf("%.1f", t.urgency)))
+			}
+		}
+	}
+	return chips
+}
+
+// matched underlines chips that match a search term.
+func (d cardDelegate) matched(style lipgloss.Style, value string) lipgloss.Style {
+	if len(matchIndexes(value, d.terms)) > 0 {
+		return style.Copy().Underline(true)
+	}
+	return style
+}
+
+func (d cardDelegate) urgencyBar(urgency float64) string {
+	filled := int(urgency / maxUrgency * urgencyBarSize)
+	filled = max(0, min(filled, urgencyBarSize))
+
+	style := d.styles.urgency
+	switch {
+	case urgency >= 10:
+		style = style.Copy().Foreground(lipgloss.Color(styles.Red))
+	case urgency >= 5:
+		style = style.Copy().Foreground(lipgloss.Color(styles.Yellow))
+	}
+	bar := strings.Repeat("▰", filled) + strings.Repeat("▱", urgencyBarSize-filled)
+	return style.Render(fmt.Sprintf("%s %.1f", bar, urgency))
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/bubbles/list"
+)
+
+func renderCard(d cardDelegate, t Task, width int) string {
+	l := list.New([]list.Item{t}, d, width, 20)
+	var b bytes.Buffer
+	d.Render(&b, l, 0, t)
+	return b.String()
+}
+
+func TestCardDelegateHeight(t *testing.T) {
+	tests := []struct {
+		name     string
+		fields   []string
+		compact  bool
+		expected int
+	}{
+		{"Compact cards", []string{"project", "urgency"}, true, 1},
+		{"Expanded cards with urgency bar", []string{"project", "urgency"}, false, 3},
+		{"Expanded cards without urgency bar", []string{"project", "tags"}, false, 2},
+		{"Unknown fields are ignored", []string{"colour", "urgency"}, false, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := newCardDelegate(CardConfig{Fields: tt.fields}, tt.compact)
+			if d.Height() != tt.expected {
+				t.Errorf("Height() = %d, want %d", d.Height(), tt.expected)
+			}
+		})
+	}
+}
+
+func TestCardDelegateRender(t *testing.T) {
+	task := Task{
+		description: "Fix login bug",
+		project:     "web",
+		tags:        []string{"bug"},
+		priority:    "H",
+		urgency:     10,
+		due:         time.Now().Add(-3*time.Hour - time.Minute),
+	}
+
+	compact := renderCard(newCardDelegate(CardConfig{Fields: []string{"priority", "project", "tags", "urgency"}}, true), task, 60)
+	if strings.Contains(compact, "\n") {
+		t.Errorf("Expected a single line for compact cards, got %q", compact)
+	}
+	for _, expected := range []string{"Fix login bug", "!H", "web", "#bug", "10.0"} {
+		if !strings.Contains(compact, expected) {
+			t.Errorf("Expected %q in the compact card %q", expected, compact)
+		}
+	}
+	if strings.Index(compact, "!H") > strings.Index(compact, "web") {
+		t.Errorf("Expected the fields in the configured order, got %q", compact)
+	}
+
+	expanded := renderCard(newCardDelegate(CardConfig{Fields: []string{"due", "urgency"}}, false), task, 60)
+	lines := strings.Split(expanded, "\n")
+	if len(lines) != 3 {
+		t.Fatalf("Expected 3 lines for expanded cards, got %q", expanded)
+	}
+	if !strings.Contains(lines[1], "3h overdue") {
+		t.Errorf("Expected the due chip on the second line, got %q", lines[1])
+	}
+	if !strings.Contains(lines[2], "▰▰▰▰▰▱▱▱▱▱ 10.0") {
+		t.Errorf("Expected a half full urgency bar, got %q", lines[2])
+	}
+
+	narrow := renderCard(newCardDelegate(CardConfig{Fields: []string{"project"}}, true), task, 12)
+	if !strings.Contains(narrow, ellipsis) {
+		t.Errorf("Expected the card to be truncated, got %q", narrow)
+	}
+}
@@ -0,0 +1,216 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// taskRefRe matches the id or the (short) uuid of a task.
+var taskRefRe = regexp.MustCompile(`^([1-9][0-9]*|[0-9a-fA-F]{8}(-[0-9a-fA-F-]*)?)$`)
+
+// loadBoard loads the board like the TUI does, with the context, the view and
+// the extra filters applied, so the subcommands see the same columns.
+func loadBoard(tw Backend, view string, filters ...string) (*Board, error) {
+	b := NewBoard(tw)
+	if view != "" {
+		if err := b.setView(view); err != nil {
+			return nil, err
+		}
+	}
+	b.initLists(filters...)
+	return b, nil
+}
+
+// runList is the `twkb list` subcommand, it prints the tasks of the board.
+func runList(tw Backend, args []string, defaultView string) error {
+	fs := flag.NewFlagSet("list", flag.ExitOnError)
+	col := fs.String("column", "", "only list the tasks of the `column`: todo, doing, done or deleted")
+	asJSON := fs.Bool("json", false, "print the tasks as JSON")
+	view := fs.String("view", defaultView, "list the named `view` of the config")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: twkb list [--column name] [--json] [--view name] [filter]")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	b, err := loadBoard(tw, *view, strings.Join(fs.Args(), " "))
+	if err != nil {
+		return err
+	}
+	cols := b.exportColumns()
+	if *col != "" {
+		s, err := parseStatus(*col)
+		if err != nil {
+			return err
+		}
+		i := slices.Index(b.statuses, s)
+		if i == -1 {
+			return fmt.Errorf("the board has no %s column", s)
+		}
+		cols = cols[i : i+1]
+	}
+
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if *col != "" {
+			return enc.Encode(cols[0].Tasks)
+		}
+		return enc.Encode(cols)
+	}
+	return writeList(os.Stdout, cols)
+}
+
+// writeList prints the tasks as a table, a task per line.
+func writeList(w io.Writer, cols []exportColumn) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tCOLUMN\tDESCRIPTION\tPROJECT\tTAGS\tDUE")
+	for _, c := range cols {
+		for _, t := range c.Tasks {
+			id := "-"
+			if t.ID != 0 {
+				id = fmt.Sprint(t.ID)
+			}
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n", id, c.Status, t.Description, t.Project, strings.Join(t.Tags, " "), formatExportDue(t.Due))
+		}
+	}
+	return tw.Flush()
+}
+
+// checkMove returns why the task can't be moved to the status, the moves are
+// the ones of the board: start and stop, finish or delete.
+func checkMove(t Task, to status) error {
+	switch {
+	case t.status == to:
+		return fmt.Errorf("'%s' is already %s", t.description, to)
+	case t.status == never:
+		return fmt.Errorf("'%s' is deleted", t.description)
+	case to == never:
+		return nil
+	case t.status == done:
+		return fmt.Errorf("'%s' is done and can't be moved back", t.description)
+	case to == inProgress && t.blocked:
+		return fmt.Errorf("'%s' is blocked", t.description)
+	}
+	return nil
+}
+
+// moveTask moves the task to the status with the same commands as the board.
+func moveTask(tw Backend, t *Task, to status) {
+	switch to {
+	case todo:
+		t.Stop(tw)
+	case inProgress:
+		t.StartStop(tw)
+	case done:
+		t.Finish(tw)
+	case never:
+		t.Delete(tw)
+	}
+}
+
+// runMove is the `twkb move` subcommand, it moves a task to another column.
+func runMove(tw Backend, args []string, defaultView string) error {
+	fs := flag.NewFlagSet("move", flag.ExitOnError)
+	force := fs.Bool("force", false, "move the task even if the column is at its WIP limit")
+	view := fs.String("view", defaultView, "count the WIP limit on the named `view` of the config")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: twkb move [--force] [--view name] <id|uuid> <todo|doing|done|deleted>")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+	if fs.NArg() != 2 {
+		fs.Usage()
+		return errors.New("move needs a task and a column")
+	}
+
+	ref := fs.Arg(0)
+	if !taskRefRe.MatchString(ref) {
+		return fmt.Errorf("%q is neither an id nor a uuid", ref)
+	}
+	to, err := parseStatus(fs.Arg(1))
+	if err != nil {
+		return err
+	}
+
+	tasks := getFromTW(tw, ref)
+	if len(tasks) == 0 {
+		return fmt.Errorf("there is no task %s", ref)
+	}
+	if len(tasks) > 1 {
+		return fmt.Errorf("%s matches %d tasks, use a longer uuid", ref, len(tasks))
+	}
+	task := tasks[0]
+	if err := checkMove(task, to); err != nil {
+		return err
+	}
+
+	if limit := wipLimit(to); limit > 0 {
+		b, err := loadBoard(tw, *view)
+		if err != nil {
+			return err
+		}
+		if wipStateOf(b.count(to)+1, limit) == overLimit && (config.WIPStrict || !*force) {
+			hint := ", use --force to move it anyway"
+			if config.WIPStrict {
+				hint = ""
+			}
+			return fmt.Errorf("%s is at its WIP limit of %d%s", to.title(), limit, hint)
+		}
+	}
+
+	moveTask(tw, &task, to)
+	fmt.Printf("moved '%s' to %s\n", task.description, to)
+	return nil
+}
+
+// summary is the line of `twkb summary`, done and deleted tasks only count
+// when they ended today.
+func summary(statuses []status, tasks []Task, now time.Time) string {
+	counts := map[status]int{}
+	today := startOfDay(now)
+	for _, t := range tasks {
+		if (t.status == done || t.status == never) && t.end.Before(today) {
+			continue
+		}
+		counts[t.status]++
+	}
+
+	var parts []string
+	for _, s := range statuses {
+		part := fmt.Sprintf("%d %s", counts[s], s)
+		if s == done || s == never {
+			part += " today"
+		}
+		parts = append(parts, part)
+	}
+	return strings.Join(parts, " • ")
+}
+
+// runSummary is the `twkb summary` subcommand, a single line for status bars
+// like tmux or polybar.
+func runSummary(tw Backend, args []string, defaultView string) error {
+	fs := flag.NewFlagSet("summary", flag.ExitOnError)
+	view := fs.String("view", defaultView, "summarize the named `view` of the config")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: twkb summary [--view name] [filter]")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	b, err := loadBoard(tw, *view, strings.Join(fs.Args(), " "))
+	if err != nil {
+		return err
+	}
+	fmt.Println(summary(b.statuses, b.tasks(), time.Now()))
+	return nil
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestCheckMove(t *testing.T) {
+	tests := []struct {
+		name    string
+		task    Task
+		to      status
+		allowed bool
+	}{
+		{"Start a task", Task{status: todo}, inProgress, true},
+		{"Stop a task", Task{status: inProgress}, todo, true},
+		{"Finish a task", Task{status: todo}, done, true},
+		{"Delete a done task", Task{status: done}, never, true},
+		{"Same column", Task{status: todo}, todo, false},
+		{"Blocked task can't start", Task{status: todo, blocked: true}, inProgress, false},
+		{"Blocked task can be finished", Task{status: todo, blocked: true}, done, true},
+		{"Blocked task can be stopped", Task{status: inProgress, blocked: true}, todo, true},
+		{"Done task can't go back", Task{status: done}, todo, false},
+		{"Deleted task stays deleted", Task{status: never}, done, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := checkMove(tt.task, tt.to); (err == nil) != tt.allowed {
+				t.Errorf("checkMove() = %v, want allowed %v", err, tt.allowed)
+			}
+		})
+	}
+}
+
+func TestMoveTask(t *testing.T) {
+	tests := []struct {
+		name     string
+		task     map[string]any
+		to       status
+		expected string
+	}{
+		{"Start a task", map[string]any{}, inProgress, "task 1 start"},
+		{"Stop a task", map[string]any{"start": "20240301T080000Z"}, todo, "task 1 stop"},
+		{"Stop a blocked task", map[string]any{"start": "20240301T080000Z", "depends": []any{"2"}}, todo, "task 1 stop"},
+		{"Finish a task", map[string]any{}, done, "task rc.confirmation=no 1 done"},
+		{"Delete a task", map[string]any{}, never, "task rc.confirmation=no 1 delete"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := newFakeBackend(tt.task)
+			task := getFromTW(fake, "1")[0]
+			if err := checkMove(task, tt.to); err != nil {
+				t.Fatalf("checkMove() = %v", err)
+			}
+			moveTask(fake, &task, tt.to)
+			requireCommands(t, fake, tt.expected)
+			if task.status != tt.to {
+				t.Errorf("expected the task to be %s, got %s", tt.to, task.status)
+			}
+		})
+	}
+}
+
+func TestTaskRefRe(t *testing.T) {
+	for _, ref := range []string{"1", "42", "a1b2c3d4", "a1b2c3d4-e5f6-4789-8abc-def012345678"} {
+		if !taskRefRe.MatchString(ref) {
+			t.Errorf("expected %q to be a task", ref)
+		}
+	}
+	for _, ref := range []string{"0", "project:work", "a1b2", "+bug", ""} {
+		if taskRefRe.MatchString(ref) {
+			t.Errorf("expected %q not to be a task", ref)
+		}
+	}
+}
+
+func TestSummary(t *testing.T) {
+	now := time.Date(2024, 3, 1, 15, 0, 0, 0, time.Local)
+	tasks := []Task{
+		{status: todo},
+		{status: todo},
+		{status: inProgress},
+		{status: done, end: now.Add(-time.Hour)},
+		{status: done, end: now.Add(-24 * time.Hour)},
+		{status: never, end: now.Add(-time.Hour)},
+	}
+
+	tests := []struct {
+		statuses []status
+		expected string
+	}{
+		{[]status{todo, inProgress, done}, "2 todo • 1 doing • 1 done today"},
+		{[]status{inProgress, done, never}, "1 doing • 1 done today • 1 deleted today"},
+		{[]status{todo}, "2 todo"},
+	}
+
+	for i, test := range tests {
+		if result := summary(test.statuses, tasks, now); result != test.expected {
+			t.Errorf("Test %d: expected %q, got %q", i+1, test.expected, result)
+		}
+	}
+}
+
+func TestWriteList(t *testing.T) {
+	var b bytes.Buffer
+	if err := writeList(&b, exportTestColumns()); err != nil {
+		t.Fatal(err)
+	}
+	expected := `ID  COLUMN  DESCRIPTION           PROJECT  TAGS         DUE
+1   todo    Write docs            work     docs urgent  2024-03-01 14:30
+-   done    Fix "quotes", <tags>                        
+`
+	if b.String() != expected {
+		t.Errorf("expected\n%s\ngot\n%s", expected, b.String())
+	}
+}
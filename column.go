@@ -3,7 +3,7 @@ package main
 import (
 	"fmt"
 	"os"
-	"os/exec"
+	"slices"
 
 	"github.com/DerTimonius/twkb/styles"
 	"github.com/charmbracelet/bubbles/key"
@@ -15,11 +15,17 @@ import (
 const APPEND = -1
 
 type column struct {
+	tw     Backend
 	list   list.Model
 	status status
 	height int
 	width  int
 	focus  bool
+	// match decides which tasks the board search shows, the others are kept
+	// in hidden until the search changes
+	match  func(Task) bool
+	hidden []list.Item
+	sortBy string
 }
 
 func (c *column) Focus() {
@@ -34,75 +40,95 @@ func (c *column) Focused() bool {
 	return c.focus
 }
 
-func newColumn(status status) column {
-	var focus bool
-	if status == todo {
-		focus = true
-	}
-	defaultDelegate := list.NewDefaultDelegate()
-	defaultDelegate.Styles.SelectedTitle = styles.DefaultSelectedTitleStyle
-	defaultDelegate.Styles.SelectedDesc = styles.DefaultSelectedDesc
-	defaultList := list.New([]list.Item{}, defaultDelegate, 0, 0)
+func newColumn(tw Backend, status status, compact bool) column {
+	defaultList := list.New([]list.Item{}, newCardDelegate(config.Card, compact), 0, 0)
 	defaultList.SetShowHelp(false)
+	// the board searches all columns at once
+	defaultList.SetFilteringEnabled(false)
 	defaultList.Styles.Title = styles.DefaultListTitleStyle
-	return column{focus: focus, status: status, list: defaultList}
+	return column{tw: tw, status: status, list: defaultList}
 }
 
 func (c column) Init() tea.Cmd {
 	return nil
 }
 
-// Update handles all the I/O for columns.
+// Update handles the I/O of the list, the actions on the cards are handled
+// by the board.
 func (c column) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
-	switch msg := msg.(type) {
-	case tea.WindowSizeMsg:
+	if msg, ok := msg.(tea.WindowSizeMsg); ok {
 		c.setSize(msg.Width, msg.Height)
-		c.list.SetSize(msg.Width/margin, msg.Height-8)
-	case tea.KeyMsg:
-		switch {
-		case key.Matches(msg, keys.Edit):
-			if len(c.list.VisibleItems()) != 0 {
-				task := c.list.SelectedItem().(Task)
-				f := NewEditForm(task)
-				f.index = c.list.Index()
-				f.col = c
-				return f.Update(nil)
-			}
-		case key.Matches(msg, keys.New):
-			f := newDefaultForm()
-			f.index = APPEND
-			f.col = c
-			return f.Update(nil)
-		case key.Matches(msg, keys.Unblock):
-			task := c.list.SelectedItem().(Task)
-			conf := NewConfirmation(fmt.Sprintf("Are you sure you want to unblock the task '%s'?", task.description), c.Unblock)
-			conf.index = APPEND
-			conf.column = c
-			return conf.Update(nil)
-		case key.Matches(msg, keys.Delete):
-			task := c.list.SelectedItem().(Task)
-			conf := NewConfirmation(fmt.Sprintf("Are you sure you want to delete the task '%s'?", task.description), c.DeleteCurrent)
-			conf.index = APPEND
-			conf.column = c
-			return conf.Update(nil)
-		case key.Matches(msg, keys.Block):
-			task := c.list.SelectedItem().(Task)
-			todoTasks := board.cols[todo].list.Items()
-			b := NewBlockForm(task, todoTasks, c.height, c.width)
-			b.index = APPEND
-			b.column = c
-			return b.Update(nil)
-		case key.Matches(msg, keys.Space):
-			return c, c.MoveToNext()
-		case key.Matches(msg, keys.Enter):
-			return c, c.MoveToDone()
-		}
 	}
 	c.list, cmd = c.list.Update(msg)
 	return c, cmd
 }
 
+// updateCard handles the keys acting on the selected card of the focused
+// column, it reports whether the key was one of them.
+func (m *Board) updateCard(msg tea.KeyMsg) (tea.Cmd, bool) {
+	c := m.focusedColumn()
+	// deleted tasks can only be looked at
+	if c.status == never {
+		return nil, false
+	}
+	task, ok := c.list.SelectedItem().(Task)
+	switch {
+	case key.Matches(msg, keys.New):
+		f := newDefaultForm()
+		f.loadCompletions(m.tw, m.tasks())
+		f.index = APPEND
+		f.col = *c
+		return openView(f), true
+	case !ok:
+		return nil, false
+	case key.Matches(msg, keys.Edit):
+		f := NewEditForm(task)
+		f.loadCompletions(m.tw, m.tasks())
+		f.index = c.list.Index()
+		f.col = *c
+		return openCardView(f), true
+	case key.Matches(msg, keys.Info):
+		return openCardView(NewTaskDetail(task)), true
+	case key.Matches(msg, keys.ToggleTags, keys.RemoveTag):
+		remove := key.Matches(msg, keys.RemoveTag)
+		if remove && len(task.tags) == 0 {
+			return nil, true
+		}
+		p := NewTagPicker(task, c.list.Index(), tagsInUse(m.tw, m.tasks()), remove, 61, m.height/2)
+		return openCardView(p), true
+	case key.Matches(msg, keys.AddTag):
+		i := NewTagInput(task, tagsInUse(m.tw, m.tasks()))
+		i.index = c.list.Index()
+		return openCardView(i), true
+	case key.Matches(msg, keys.Unblock):
+		conf := NewConfirmation(fmt.Sprintf("Are you sure you want to unblock the task '%s'?", task.description), unblockMsg{})
+		return openCardView(conf), true
+	case key.Matches(msg, keys.Delete):
+		conf := NewConfirmation(fmt.Sprintf("Are you sure you want to delete the task '%s'?", task.description), deleteMsg{})
+		return openCardView(conf), true
+	case key.Matches(msg, keys.Block):
+		var todoTasks []list.Item
+		for _, todoCol := range m.allColumns() {
+			if todoCol.status == todo {
+				todoTasks = append(todoTasks, convertToListItems(todoCol.tasks())...)
+			}
+		}
+		b := NewBlockForm(task, todoTasks, 61, m.height/2)
+		b.index = APPEND
+		return openCardView(b), true
+	case key.Matches(msg, keys.Space):
+		target := inProgress
+		if c.status == inProgress {
+			target = todo
+		}
+		return m.guardWIP(target), true
+	case key.Matches(msg, keys.Enter):
+		return m.guardWIP(done), true
+	}
+	return nil, false
+}
+
 func (c column) View() string {
 	return c.getStyle().Render(c.list.View())
 }
@@ -125,8 +151,7 @@ func (c *column) DeleteCurrent() tea.Cmd {
 		os.Exit(1)
 	}
 
-	cmd := exec.Command(cmdStr[0], cmdStr[1:]...)
-	err = cmd.Run()
+	_, err = c.tw.Run(cmdStr)
 	if err != nil {
 		fmt.Println(err)
 		os.Exit(1)
@@ -145,7 +170,7 @@ func (c *column) Unblock() tea.Cmd {
 		return nil
 	}
 
-	task.UnblockTask()
+	task.UnblockTask(c.tw)
 	task.blocked = false
 
 	c.list.SetItem(c.list.Index(), task)
@@ -155,15 +180,63 @@ func (c *column) Unblock() tea.Cmd {
 }
 
 func (c *column) Set(i int, t Task) tea.Cmd {
+	if i == APPEND && c.match != nil && !c.match(t) {
+		c.hidden = append(c.hidden, t)
+		return nil
+	}
 	if i != APPEND {
 		return c.list.SetItem(i, t)
 	}
 	return c.list.InsertItem(APPEND, t)
 }
 
+// tasks returns all tasks of the column, including the ones hidden by the
+// search.
+func (c *column) tasks() []Task {
+	var tasks []Task
+	for _, item := range slices.Concat(c.list.Items(), c.hidden) {
+		tasks = append(tasks, item.(Task))
+	}
+	return tasks
+}
+
+// filter shows only the tasks that match, nil shows all tasks again.
+func (c *column) filter(match func(Task) bool) {
+	tasks := c.tasks()
+	c.sort(tasks)
+
+	c.match = match
+	c.hidden = nil
+	var visible []list.Item
+	for _, t := range tasks {
+		if match == nil || match(t) {
+			visible = append(visible, t)
+		} else {
+			c.hidden = append(c.hidden, t)
+		}
+	}
+	c.list.SetItems(visible)
+	c.list.Select(max(0, min(c.list.Index(), len(visible)-1)))
+}
+
+// sort sorts the tasks in the order of the column, done and deleted tasks keep
+// the order of taskwarrior.
+func (c *column) sort(tasks []Task) {
+	if c.status == todo || c.status == inProgress {
+		sortTasks(tasks, c.sortBy)
+	}
+}
+
+// setSize sets the outer size of the column, the list gets the space that is
+// left inside of the border and padding.
 func (c *column) setSize(width, height int) {
-	c.width = width / margin
-	c.height = height - (margin * 2)
+	frameWidth, frameHeight := c.getStyle().GetFrameSize()
+	c.width = max(0, width-frameWidth+styles.ColumnBaseStyle.GetHorizontalPadding())
+	c.height = max(0, height-frameHeight+styles.ColumnBaseStyle.GetVerticalPadding())
+	c.list.SetSize(
+		max(0, c.width-styles.ColumnBaseStyle.GetHorizontalPadding()),
+		max(0, c.height-styles.ColumnBaseStyle.GetVerticalPadding()),
+	)
 }
 
 func (c *column) getStyle() lipgloss.Style {
@@ -181,10 +254,50 @@ func (c *column) getStyle() lipgloss.Style {
 		Width(c.width)
 }
 
+// guardWIP moves the selected card unless it takes the target column over its
+// WIP limit, then it asks for confirmation first or refuses the move in strict
+// mode.
+func (m *Board) guardWIP(target status) tea.Cmd {
+	c := m.focusedColumn()
+	task, ok := c.list.SelectedItem().(Task)
+	limit := wipLimit(target)
+	if !ok || task.blocked || c.status == done || limit == 0 || wipStateOf(m.count(target)+1, limit) != overLimit {
+		return m.move(target)
+	}
+
+	if config.WIPStrict {
+		return notify(fmt.Sprintf("%s is at its WIP limit of %d", target.title(), limit))
+	}
+	conf := NewConfirmation(
+		fmt.Sprintf("%s is at its WIP limit of %d, move '%s' anyway?", target.title(), limit, task.description),
+		confirmedMoveMsg{target},
+	)
+	return openCardView(conf)
+}
+
+// move starts, stops or finishes the selected card of the focused column.
+func (m *Board) move(target status) tea.Cmd {
+	if target == done {
+		return m.focusedColumn().MoveToDone()
+	}
+	return m.focusedColumn().MoveToNext()
+}
+
 type moveMsg struct {
 	Task
 }
 
+// confirmedMoveMsg moves the selected card over the WIP limit of the target.
+type confirmedMoveMsg struct {
+	target status
+}
+
+// deleteMsg and unblockMsg are the confirmed actions on the selected card.
+type (
+	deleteMsg  struct{}
+	unblockMsg struct{}
+)
+
 func (c *column) MoveToNext() tea.Cmd {
 	var task Task
 	var ok bool
@@ -194,13 +307,13 @@ func (c *column) MoveToNext() tea.Cmd {
 	}
 
 	// Don't move the task if it is in the done column
-	if task.status == done {
+	if task.status == done || task.status == never {
 		return nil
 	}
 
 	// move item
 	c.list.RemoveItem(c.list.Index())
-	task.StartStop()
+	task.StartStop(c.tw)
 
 	// refresh list
 	var cmd tea.Cmd
@@ -218,7 +331,7 @@ func (c *column) MoveToDone() tea.Cmd {
 	}
 
 	c.list.RemoveItem(c.list.Index())
-	task.Finish()
+	task.Finish(c.tw)
 
 	// refresh list
 	var cmd tea.Cmd
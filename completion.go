@@ -0,0 +1,178 @@
+package main
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/DerTimonius/twkb/styles"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const maxSuggestions = 5
+
+// dateSynonyms are the named dates taskwarrior understands, offered as
+// suggestions for the date fields.
+var dateSynonyms = []string{
+	"now", "today", "sod", "eod", "yesterday", "tomorrow",
+	"monday", "tuesday", "wednesday", "thursday", "friday", "saturday", "sunday",
+	"sow", "eow", "soww", "eoww", "som", "eom", "soq", "eoq", "soy", "eoy",
+	"later", "someday", "now+1wk", "now+1mo", "now+1yr",
+}
+
+// completion holds the suggestions for a single textinput. For multi value
+// inputs like the labels only the last word gets completed.
+type completion struct {
+	candidates []string
+	matches    []string
+	selected   int
+	multi      bool
+}
+
+func newCompletion(candidates []string, multi bool) *completion {
+	slices.Sort(candidates)
+	return &completion{candidates: slices.Compact(candidates), multi: multi}
+}
+
+func (c *completion) word(value string) string {
+	if !c.multi {
+		return value
+	}
+	if value == "" || strings.HasSuffix(value, " ") {
+		return ""
+	}
+	fields := strings.Fields(value)
+	return fields[len(fields)-1]
+}
+
+// update refreshes the suggestions for the current value of the input.
+func (c *completion) update(value string) {
+	c.matches = nil
+	c.selected = 0
+
+	word := strings.ToLower(c.word(value))
+	if word == "" {
+		return
+	}
+
+	var used []string
+	if c.multi {
+		used = strings.Fields(value)
+	}
+	for _, candidate := range c.candidates {
+		if len(c.matches) == maxSuggestions {
+			break
+		}
+		if candidate == c.word(value) || slices.Contains(used, candidate) {
+			continue
+		}
+		if strings.HasPrefix(strings.ToLower(candidate), word) {
+			c.matches = append(c.matches, candidate)
+		}
+	}
+}
+
+func (c *completion) next() {
+	if len(c.matches) > 0 {
+		c.selected = (c.selected + 1) % len(c.matches)
+	}
+}
+
+func (c *completion) prev() {
+	if len(c.matches) > 0 {
+		c.selected = (c.selected - 1 + len(c.matches)) % len(c.matches)
+	}
+}
+
+// accept returns the value with the selected suggestion filled in.
+func (c *completion) accept(value string) (string, bool) {
+	if len(c.matches) == 0 {
+		return value, false
+	}
+	suggestion := c.matches[c.selected]
+	if !c.multi {
+		return suggestion, true
+	}
+	return strings.TrimSuffix(value, c.word(value)) + suggestion, true
+}
+
+func (c *completion) View() string {
+	if c == nil || len(c.matches) == 0 {
+		return ""
+	}
+	rows := make([]string, len(c.matches))
+	for i, m := range c.matches {
+		if i == c.selected {
+			rows[i] = styles.SelectedSuggestionStyle.Render("> " + m)
+		} else {
+			rows[i] = styles.SuggestionStyle.Render(m)
+		}
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, rows...)
+}
+
+func ProjectsCmd() []string {
+	return []string{"task", "_projects"}
+}
+
+func TagsCmd() []string {
+	return []string{"task", "_tags"}
+}
+
+func CalcCmd(expr string) []string {
+	return []string{"task", "calc", expr}
+}
+
+// getCompletionWords returns the lines of a helper command like `task _tags`,
+// it returns nil if the command fails.
+func getCompletionWords(tw Backend, cmdStr []string) []string {
+	output, err := tw.Run(cmdStr)
+	if err != nil {
+		return nil
+	}
+	return strings.Fields(output)
+}
+
+// dateCalc is the result of resolving a date expression with `task calc`.
+type dateCalc struct {
+	err   error
+	date  time.Time
+	field string
+	expr  string
+}
+
+// resolveDate resolves a date expression like `eow`, `2d` or `now+1yr` to an
+// absolute date. Durations like `2d` are relative to now, just like
+// taskwarrior treats them for `due:2d`.
+func resolveDate(tw Backend, expr string) (time.Time, error) {
+	output, err := tw.Run(CalcCmd(expr))
+	if err != nil {
+		return time.Time{}, err
+	}
+	result := strings.TrimSpace(output)
+	if date, err := time.ParseInLocation(isoDateFormat, result, time.Local); err == nil {
+		return date, nil
+	}
+
+	if strings.HasPrefix(result, "P") || strings.HasPrefix(result, "-P") {
+		output, err = tw.Run(CalcCmd("now+" + expr))
+		if err != nil {
+			return time.Time{}, err
+		}
+		if date, err := time.ParseInLocation(isoDateFormat, strings.TrimSpace(output), time.Local); err == nil {
+			return date, nil
+		}
+	}
+	return time.Time{}, errors.New("not a valid date")
+}
+
+// calcDate resolves the expression in the background, the result is sent back
+// to the form as a dateCalc message.
+func calcDate(tw Backend, field, expr string) tea.Cmd {
+	return func() tea.Msg {
+		date, err := resolveDate(tw, expr)
+		return dateCalc{field: field, expr: expr, date: date, err: err}
+	}
+}
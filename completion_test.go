@@ -0,0 +1,57 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+type completionTest struct {
+	name     string
+	value    string
+	expected string
+	accepted string
+	multi    bool
+}
+
+func TestCompletion(t *testing.T) {
+	candidates := []string{"work", "web", "home", "work.web", "website"}
+
+	tests := []completionTest{
+		{"No suggestions for an empty value", "", "", "", false},
+		{"Prefix matches", "we", "web,website", "web", false},
+		{"Case insensitive", "WO", "work,work.web", "work", false},
+		{"Exact match is not suggested", "home", "", "home", false},
+		{"Only the last word is completed", "home we", "web,website", "home web", true},
+		{"Used values are not suggested", "web w", "website,work,work.web", "web website", true},
+		{"Nothing to complete after a space", "web ", "", "web ", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newCompletion(append([]string{}, candidates...), tt.multi)
+			c.update(tt.value)
+			if strings.Join(c.matches, ",") != tt.expected {
+				t.Errorf("update(%q) = %q, want %q", tt.value, c.matches, tt.expected)
+			}
+			if accepted, _ := c.accept(tt.value); accepted != tt.accepted {
+				t.Errorf("accept(%q) = %q, want %q", tt.value, accepted, tt.accepted)
+			}
+		})
+	}
+}
+
+func TestCompletionSelection(t *testing.T) {
+	c := newCompletion([]string{"eod", "eow", "eom"}, false)
+	c.update("e")
+
+	c.next()
+	if accepted, _ := c.accept("e"); accepted != "eom" {
+		t.Errorf("Expected the second suggestion, got %q", accepted)
+	}
+
+	c.prev()
+	c.prev()
+	if accepted, _ := c.accept("e"); accepted != "eow" {
+		t.Errorf("Expected the last suggestion, got %q", accepted)
+	}
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// Config holds the user settings read from $XDG_CONFIG_HOME/twkb/config.json.
+// Every field is optional, a missing file results in the defaults.
+type Config struct {
+	// Timewarrior enables reading the tracked time from `timew export`.
+	Timewarrior bool `json:"timewarrior"`
+	// DueFormat is either "relative" (in 3d, 2h overdue), "absolute" or "both".
+	DueFormat string `json:"dueFormat"`
+	// Columns are the statuses shown as columns, in this order.
+	Columns []string `json:"columns"`
+	// WIPLimits are the maximum number of tasks per column, e.g. {"doing": 3}.
+	WIPLimits map[string]int `json:"wipLimits"`
+	// WIPStrict refuses to move a task over the limit instead of asking.
+	WIPStrict bool `json:"wipStrict"`
+	// Aging highlights the cards that are in a column for at least the given
+	// number of days, e.g. {"doing": 7}. 0 turns the highlight off.
+	Aging map[string]int `json:"aging"`
+	// Swimlanes groups the board into lanes by project, tag, priority or the
+	// name of a UDA. Empty shows a single lane.
+	Swimlanes string `json:"swimlanes"`
+	// Views are named presets of a filter, the columns, the sort order and
+	// the swimlanes, see View.
+	Views map[string]View `json:"views"`
+	// Card sets the fields, colours and the default layout of the cards.
+	Card CardConfig `json:"card"`
+}
+
+// View is a named perspective on the board. Empty fields keep the settings
+// of the config.
+type View struct {
+	// Filter is a taskwarrior filter, applied on top of the context.
+	Filter string `json:"filter"`
+	// Columns are the statuses shown as columns, like Config.Columns.
+	Columns []string `json:"columns"`
+	// Sort is the order of the tasks, one of sortOrders.
+	Sort string `json:"sort"`
+	// Swimlanes groups the view into lanes, like Config.Swimlanes.
+	Swimlanes string `json:"swimlanes"`
+}
+
+var config Config
+
+func defaultConfig() Config {
+	return Config{
+		DueFormat: "relative",
+		Columns:   []string{"todo", "doing", "done"},
+		Aging:     map[string]int{"todo": 30, "doing": 7},
+		Card:      defaultCardConfig(),
+	}
+}
+
+// columnStatuses returns the statuses of the columns with the given names.
+func columnStatuses(names []string) []status {
+	var statuses []status
+	for _, name := range names {
+		s, err := parseStatus(name)
+		if err != nil {
+			continue
+		}
+		statuses = append(statuses, s)
+	}
+	if len(statuses) == 0 {
+		return []status{todo, inProgress, done}
+	}
+	return statuses
+}
+
+func configPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "twkb", "config.json"), nil
+}
+
+func loadConfig() (Config, error) {
+	c := defaultConfig()
+	path, err := configPath()
+	if err != nil {
+		return c, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return c, nil
+	}
+	if err != nil {
+		return c, err
+	}
+
+	if err := json.Unmarshal(data, &c); err != nil {
+		return c, err
+	}
+	for _, name := range c.Columns {
+		if _, err := parseStatus(name); err != nil {
+			return c, err
+		}
+	}
+	for name := range c.WIPLimits {
+		if _, err := parseStatus(name); err != nil {
+			return c, err
+		}
+	}
+	for name := range c.Aging {
+		if _, err := parseStatus(name); err != nil {
+			return c, err
+		}
+	}
+	for name, v := range c.Views {
+		for _, column := range v.Columns {
+			if _, err := parseStatus(column); err != nil {
+				return c, fmt.Errorf("view %s: %w", name, err)
+			}
+		}
+		if v.Sort != "" && !slices.Contains(sortOrders, v.Sort) {
+			return c, fmt.Errorf("view %s: unknown sort order %q, use %s", name, v.Sort, strings.Join(sortOrders, ", "))
+		}
+	}
+	return c, nil
+}
@@ -8,23 +8,21 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 )
 
+// Confirmation asks before an action, the board gets the confirmed message
+// when the answer is yes.
 type Confirmation struct {
-	confirm func() tea.Cmd
-	message string
-	column  column
-	index   int
+	confirmed tea.Msg
+	message   string
 }
 
 func (c Confirmation) Init() tea.Cmd {
 	return nil
 }
 
-func NewConfirmation(message string, confirm func() tea.Cmd) *Confirmation {
+func NewConfirmation(message string, confirmed tea.Msg) *Confirmation {
 	return &Confirmation{
-		confirm: confirm,
-		message: message,
-		column:  column{},
-		index:   0,
+		confirmed: confirmed,
+		message:   message,
 	}
 }
 
@@ -33,9 +31,9 @@ func (c Confirmation) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.KeyMsg:
 		switch {
 		case key.Matches(msg, keys.Yes):
-			return board.Update(c)
+			return c, submit(c.confirmed)
 		case key.Matches(msg, keys.No), key.Matches(msg, keys.Back):
-			return board.Update(nil)
+			return c, closeView
 		case key.Matches(msg, keys.Quit):
 			return c, tea.Quit
 		}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const noContext = "none"
+
+// twContext is a taskwarrior context as defined with `task context define`.
+type twContext struct {
+	name   string
+	filter string
+}
+
+// implement the list.Item interface
+func (c twContext) FilterValue() string {
+	return c.name
+}
+
+func (c twContext) Title() string {
+	return c.name
+}
+
+func (c twContext) Description() string {
+	if c.filter == "" {
+		return "show all tasks"
+	}
+	return c.filter
+}
+
+func ContextListCmd() []string {
+	return []string{"task", "_context"}
+}
+
+func ActiveContextCmd() []string {
+	return []string{"task", "_get", "rc.context"}
+}
+
+// ContextFilterCmd returns the command to read the filter of a context.
+// Taskwarrior 2.6 split the filter into a read and a write filter, older
+// versions store it directly under rc.context.<name>.
+func ContextFilterCmd(name string, legacy bool) ([]string, error) {
+	if name == "" || strings.ContainsAny(name, " \t") {
+		return []string{}, fmt.Errorf("invalid context name %q", name)
+	}
+	if legacy {
+		return []string{"task", "_get", fmt.Sprintf("rc.context.%s", name)}, nil
+	}
+	return []string{"task", "_get", fmt.Sprintf("rc.context.%s.read", name)}, nil
+}
+
+func SetContextCmd(name string) ([]string, error) {
+	if name == "" || strings.ContainsAny(name, " \t") {
+		return []string{}, fmt.Errorf("invalid context name %q", name)
+	}
+	return []string{"task", "rc.confirmation=no", "context", name}, nil
+}
+
+func getActiveContext(tw Backend) string {
+	output, err := tw.Run(ActiveContextCmd())
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(output)
+}
+
+func getContextFilter(tw Backend, name string) string {
+	for _, legacy := range []bool{false, true} {
+		cmdStr, err := ContextFilterCmd(name, legacy)
+		if err != nil {
+			return ""
+		}
+		output, err := tw.Run(cmdStr)
+		if err == nil && strings.TrimSpace(output) != "" {
+			return strings.TrimSpace(output)
+		}
+	}
+	return ""
+}
+
+func getContexts(tw Backend) ([]twContext, error) {
+	output, err := tw.Run(ContextListCmd())
+	if err != nil {
+		return nil, err
+	}
+
+	contexts := []twContext{{name: noContext}}
+	for _, name := range strings.Fields(output) {
+		contexts = append(contexts, twContext{name: name, filter: getContextFilter(tw, name)})
+	}
+	return contexts, nil
+}
+
+// contextSelectedMsg is the result of the context picker.
+type contextSelectedMsg struct {
+	name string
+}
+
+// ContextPicker lists the defined contexts and switches to the selected one.
+type ContextPicker = Picker[twContext]
+
+func NewContextPicker(contexts []twContext, active string, width, height int) *ContextPicker {
+	selected := 0
+	for i, c := range contexts {
+		if c.name == active {
+			selected = i
+		}
+	}
+
+	p := newPicker("Switch context", contexts, pickerDelegate(), width, height)
+	p.list.Select(selected)
+	p.picked = func(c twContext) tea.Msg { return contextSelectedMsg{name: c.name} }
+	return p
+}
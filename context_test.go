@@ -0,0 +1,85 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type contextTest struct {
+	name     string
+	expected string
+	context  string
+	legacy   bool
+	isErr    bool
+}
+
+func TestContextFilterCmd(t *testing.T) {
+	tests := []contextTest{
+		{"Read filter", "task _get rc.context.work.read", "work", false, false},
+		{"Legacy filter", "task _get rc.context.work", "work", true, false},
+		{"Empty context name", "", "", false, true},
+		{"Context name with spaces", "", "my work", false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ContextFilterCmd(tt.context, tt.legacy)
+			if tt.isErr {
+				if err == nil {
+					t.Fatal("Expected an error, but got nil")
+				}
+				return
+			}
+			if strings.Join(result, " ") != tt.expected {
+				t.Errorf("ContextFilterCmd(%v) = %q, want %q", tt.context, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSetContextCmd(t *testing.T) {
+	tests := []contextTest{
+		{"Switch context", "task rc.confirmation=no context work", "work", false, false},
+		{"Clear context", "task rc.confirmation=no context none", noContext, false, false},
+		{"Empty context name", "", "", false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := SetContextCmd(tt.context)
+			if tt.isErr {
+				if err == nil {
+					t.Fatal("Expected an error, but got nil")
+				}
+				return
+			}
+			if strings.Join(result, " ") != tt.expected {
+				t.Errorf("SetContextCmd(%v) = %q, want %q", tt.context, result, tt.expected)
+			}
+		})
+	}
+}
+
+// brokenBackend fails every command, like a missing or broken taskwarrior.
+type brokenBackend struct{}
+
+func (brokenBackend) Run(cmd []string) (string, error) {
+	return "", errors.New("task: command failed")
+}
+
+func TestContextErrors(t *testing.T) {
+	b := NewBoard(brokenBackend{})
+
+	_, cmd := b.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'c'}})
+	if notice := noticeOf(cmd); notice != "Could not read the contexts: task: command failed" {
+		t.Errorf("expected a notice about the contexts, got %q", notice)
+	}
+
+	_, cmd = b.Update(contextSelectedMsg{name: "work"})
+	if notice := noticeOf(cmd); notice != "Could not switch to the context work: task: command failed" {
+		t.Errorf("expected a notice about the switch, got %q", notice)
+	}
+}
@@ -1,69 +1,81 @@
 package main
 
 import (
-	"bytes"
 	"cmp"
 	"encoding/json"
 	"fmt"
+	"log"
 	"os"
-	"os/exec"
 	"slices"
+	"strings"
 	"time"
 
 	"github.com/charmbracelet/bubbles/list"
 )
 
-func (b *Board) initLists() {
-	tasks := getFromTW()
-	var todoTasks []Task
-	var doingTasks []Task
-	var doneTasks []Task
-	// var neverTasks []TwTask
+// initLists loads the tasks of the active context and view into the columns,
+// extra filters narrow them down further.
+func (b *Board) initLists(extra ...string) {
+	b.context = getActiveContext(b.tw)
+	b.filters = nil
+	if b.context != "" {
+		b.filters = append(b.filters, getContextFilter(b.tw, b.context))
+	}
+	if b.filter != "" {
+		b.filters = append(b.filters, b.filter)
+	}
+	for _, f := range extra {
+		if strings.TrimSpace(f) != "" {
+			b.filters = append(b.filters, f)
+		}
+	}
+
+	tasks := getFromTW(b.tw, b.filters...)
+	if config.Timewarrior {
+		applyTimewarrior(b.tw, tasks)
+	}
 
+	b.focused = 0
+	b.build(tasks)
+}
+
+// newColumns puts the tasks into a column per status.
+func (b *Board) newColumns(tasks []Task) []column {
+	grouped := map[status][]Task{}
 	for _, t := range tasks {
-		switch t.status {
-		case done:
-			doneTasks = append(doneTasks, t)
-		case inProgress:
-			doingTasks = append(doingTasks, t)
-		case todo:
-			todoTasks = append(todoTasks, t)
-			// default:
-			// 	neverTasks = append(neverTasks, t)
-		}
-	}
-
-	sortTasks(todoTasks)
-	sortTasks(doingTasks)
-
-	// TODO: add a never column
-	b.cols = []column{
-		newColumn(todo),
-		newColumn(inProgress),
-		newColumn(done),
-	}
-
-	// Init To Do
-	b.cols[todo].list.Title = "To Do"
-	b.cols[todo].list.SetItems(convertToListItems(todoTasks))
-	// Init in progress
-	b.cols[inProgress].list.Title = "In Progress"
-	b.cols[inProgress].list.SetItems(convertToListItems(doingTasks))
-	// Init done
-	b.cols[done].list.Title = "Done"
-	b.cols[done].list.SetItems(convertToListItems(doneTasks))
+		grouped[t.status] = append(grouped[t.status], t)
+	}
+
+	cols := make([]column, len(b.statuses))
+	for i, s := range b.statuses {
+		cols[i] = newColumn(b.tw, s, b.compact)
+		cols[i].sortBy = b.sortBy
+		cols[i].sort(grouped[s])
+		cols[i].list.Title = s.title()
+		cols[i].list.SetItems(convertToListItems(grouped[s]))
+	}
+	return cols
 }
 
-func getFromTW() []Task {
-	cmd := exec.Command("task", "export")
-	var out bytes.Buffer
-	cmd.Stdout = &out
-	err := cmd.Run()
+// ExportCmd returns the export command for the given filters. Each filter is
+// wrapped in parentheses so that `or` in one filter doesn't leak into another.
+func ExportCmd(filters ...string) []string {
+	cmd := []string{"task"}
+	for _, f := range filters {
+		if strings.TrimSpace(f) == "" {
+			continue
+		}
+		cmd = append(cmd, fmt.Sprintf("(%s)", strings.TrimSpace(f)))
+	}
+	return append(cmd, "export")
+}
+
+func getFromTW(tw Backend, filters ...string) []Task {
+	output, err := tw.Run(ExportCmd(filters...))
 	if err != nil {
 		fmt.Println(err)
 		os.Exit(1)
 	}
-	output := out.String()
 	var result []map[string]interface{}
 	err = json.Unmarshal([]byte(output), &result)
 	if err != nil {
@@ -78,9 +90,7 @@ func getFromTW() []Task {
 		if _, ok := v["mask"].(string); ok {
 			continue
 		}
-		if start, ok := v["start"].(string); ok {
-			task.start = start
-		}
+		task.start = parseTWDate(v["start"])
 		if modified, ok := v["modified"].(string); ok {
 			task.modified = modified
 		}
@@ -93,7 +103,7 @@ func getFromTW() []Task {
 		if status, ok := v["status"].(string); ok {
 			if status == "completed" {
 				task.status = done
-			} else if status == "pending" && task.start != "" {
+			} else if status == "pending" && !task.start.IsZero() {
 				task.status = inProgress
 			} else if status == "deleted" {
 				task.status = never
@@ -101,13 +111,21 @@ func getFromTW() []Task {
 				task.status = todo
 			}
 		}
-		if due, ok := v["due"].(string); ok {
-			t, _ := time.Parse("20060102T150405Z", due)
-			task.due = fmt.Sprintf("%.1fd", time.Until(t).Hours()/24)
-		}
+		task.entry = parseTWDate(v["entry"])
+		task.end = parseTWDate(v["end"])
+		task.due = parseTWDate(v["due"])
 		if project, ok := v["project"].(string); ok {
 			task.project = project
 		}
+		if recur, ok := v["recur"].(string); ok {
+			task.recur = recur
+		}
+		if priority, ok := v["priority"].(string); ok {
+			task.priority = priority
+		}
+		task.until = parseTWDate(v["until"])
+		task.wait = parseTWDate(v["wait"])
+		task.scheduled = parseTWDate(v["scheduled"])
 		if rtype, ok := v["rtype"].(string); ok {
 			if rtype == "periodic" {
 				task.recurring = true
@@ -122,6 +140,25 @@ func getFromTW() []Task {
 		if urgency, ok := v["urgency"].(float64); ok {
 			task.urgency = urgency
 		}
+		for name, value := range v {
+			if slices.Contains(knownAttributes, name) {
+				continue
+			}
+			switch value := value.(type) {
+			case string, float64:
+				if task.udas == nil {
+					task.udas = map[string]string{}
+				}
+				task.udas[name] = fmt.Sprint(value)
+			}
+		}
+		if annotations, ok := v["annotations"].([]interface{}); ok {
+			for _, annotation := range annotations {
+				if a, ok := annotation.(map[string]interface{}); ok {
+					task.annotations = append(task.annotations, fmt.Sprint(a["description"]))
+				}
+			}
+		}
 		if tags, ok := v["tags"].([]interface{}); ok {
 			for _, tag := range tags {
 				if t, ok := tag.(string); ok {
@@ -135,6 +172,52 @@ func getFromTW() []Task {
 	return tasks
 }
 
+// knownAttributes are the attributes of `task export` that are not UDAs.
+var knownAttributes = []string{
+	"id", "uuid", "description", "status", "entry", "modified", "start", "end",
+	"due", "until", "wait", "scheduled", "recur", "rtype", "mask", "imask",
+	"parent", "project", "priority", "tags", "depends", "urgency", "annotations",
+}
+
+// applyTimewarrior adds the tracked time to the tasks. Timewarrior is optional,
+// so failing to read it only gets logged.
+func applyTimewarrior(tw Backend, tasks []Task) {
+	intervals, err := getFromTimew(tw)
+	if err != nil {
+		log.Printf("could not read timewarrior intervals: %v", err)
+		return
+	}
+	now := time.Now()
+	for i := range tasks {
+		tasks[i].applyTracking(intervals, now)
+	}
+}
+
+// isoDateFormat is used to show dates and to pass them back to taskwarrior,
+// it is also the format `task calc` prints dates in.
+const isoDateFormat = "2006-01-02T15:04:05"
+
+// parseTWDate parses a date of `task export`, it returns the zero time for
+// missing or invalid values.
+func parseTWDate(v interface{}) time.Time {
+	s, ok := v.(string)
+	if !ok {
+		return time.Time{}
+	}
+	t, err := time.Parse(twTimeFormat, s)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+func formatTWDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Local().Format(isoDateFormat)
+}
+
 func convertToListItems(tasks []Task) []list.Item {
 	items := make([]list.Item, len(tasks))
 	for i, task := range tasks {
@@ -143,8 +226,29 @@ func convertToListItems(tasks []Task) []list.Item {
 	return items
 }
 
-func sortTasks(tasks []Task) {
-	slices.SortFunc(tasks, func(a, b Task) int {
-		return cmp.Compare(a.urgency, b.urgency) * -1
+// sortOrders are the orders the tasks can be sorted in, the first one is the
+// default.
+var sortOrders = []string{"urgency", "due", "priority", "project", "description"}
+
+// sortTasks sorts the tasks in the given order. Tasks without due date or
+// priority come last, ties are sorted by urgency.
+func sortTasks(tasks []Task, by string) {
+	priorities := []string{"H", "M", "L", ""}
+	slices.SortStableFunc(tasks, func(a, b Task) int {
+		var c int
+		switch by {
+		case "due":
+			c = cmp.Or(
+				cmp.Compare(boolInt(a.due.IsZero()), boolInt(b.due.IsZero())),
+				a.due.Compare(b.due),
+			)
+		case "priority":
+			c = cmp.Compare(slices.Index(priorities, a.priority), slices.Index(priorities, b.priority))
+		case "project":
+			c = cmp.Compare(a.project, b.project)
+		case "description":
+			c = cmp.Compare(strings.ToLower(a.description), strings.ToLower(b.description))
+		}
+		return cmp.Or(c, cmp.Compare(a.urgency, b.urgency)*-1)
 	})
 }
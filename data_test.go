@@ -0,0 +1,41 @@
+package main
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestSortTasks(t *testing.T) {
+	now := time.Now()
+	tasks := []Task{
+		{description: "b", project: "work", priority: "L", urgency: 4, due: now.Add(2 * time.Hour)},
+		{description: "A", project: "home", urgency: 9},
+		{description: "c", project: "home", priority: "H", urgency: 1, due: now.Add(time.Hour)},
+		{description: "d", project: "errand", priority: "L", urgency: 6},
+	}
+	tests := []struct {
+		by       string
+		expected []string
+	}{
+		{"urgency", []string{"A", "d", "b", "c"}},
+		{"due", []string{"c", "b", "A", "d"}},
+		{"priority", []string{"c", "d", "b", "A"}},
+		{"project", []string{"d", "A", "c", "b"}},
+		{"description", []string{"A", "b", "c", "d"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.by, func(t *testing.T) {
+			sorted := slices.Clone(tasks)
+			sortTasks(sorted, tt.by)
+			var descriptions []string
+			for _, task := range sorted {
+				descriptions = append(descriptions, task.description)
+			}
+			if !slices.Equal(descriptions, tt.expected) {
+				t.Errorf("sortTasks(%q) = %q, want %q", tt.by, descriptions, tt.expected)
+			}
+		})
+	}
+}
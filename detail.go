@@ -0,0 +1,121 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/DerTimonius/twkb/styles"
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// TaskDetail shows all attributes of a task.
+type TaskDetail struct {
+	help help.Model
+	task Task
+	now  time.Time
+}
+
+func NewTaskDetail(t Task) *TaskDetail {
+	return &TaskDetail{help: help.New(), task: t, now: time.Now()}
+}
+
+func (d TaskDetail) Init() tea.Cmd {
+	return nil
+}
+
+func (d TaskDetail) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, keys.Back), key.Matches(msg, keys.Info):
+			return d, closeView
+		case key.Matches(msg, keys.Quit):
+			return d, tea.Quit
+		}
+	}
+	return d, nil
+}
+
+// rows returns the attributes of the task that are set, as label and value.
+func (d TaskDetail) rows() [][2]string {
+	t := d.task
+	var rows [][2]string
+	add := func(label, value string) {
+		if value != "" {
+			rows = append(rows, [2]string{label, value})
+		}
+	}
+	date := func(v time.Time) string {
+		if v.IsZero() {
+			return ""
+		}
+		return v.Local().Format("Mon, 2006-01-02 15:04")
+	}
+
+	if t.id != 0 {
+		add("ID", fmt.Sprint(t.id))
+	}
+	add("UUID", t.uuid)
+	add("Status", t.status.title())
+	add("Project", t.project)
+	if len(t.tags) > 0 {
+		add("Tags", "#"+strings.Join(t.tags, " #"))
+	}
+	add("Priority", t.priority)
+	if !t.due.IsZero() {
+		add("Due", fmt.Sprintf("%s (%s)", date(t.due), relativeDue(t.due, d.now)))
+	}
+	add("Scheduled", date(t.scheduled))
+	add("Wait", date(t.wait))
+	if t.recur != "" {
+		add("Recur", t.recur)
+		add("Until", date(t.until))
+	}
+	add("Entered", date(t.entry))
+	add("Started", date(t.start))
+	add("Ended", date(t.end))
+	if age := t.age(d.now); age > 0 {
+		add("In column", humanizeDuration(age))
+	}
+	if t.tracked > 0 || !t.trackingSince.IsZero() {
+		add("Tracked", fmt.Sprintf("%s (today %s)", formatDuration(t.TrackedTotal(d.now)), formatDuration(t.TrackedToday(d.now))))
+	}
+	if t.blocked {
+		add("Blocked", "yes")
+	}
+	add("Urgency", fmt.Sprintf("%.1f", t.urgency))
+	names := make([]string, 0, len(t.udas))
+	for name := range t.udas {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		add(name, t.udas[name])
+	}
+	return rows
+}
+
+func (d TaskDetail) View() string {
+	var b strings.Builder
+	for _, row := range d.rows() {
+		fmt.Fprintf(&b, "%-12s %s\n", row[0], row[1])
+	}
+	for _, annotation := range d.task.annotations {
+		fmt.Fprintf(&b, "\n• %s", annotation)
+	}
+
+	return styles.FormStyle.Render(
+		lipgloss.JoinVertical(
+			lipgloss.Left,
+			styles.TitleStyle.Render(d.task.description),
+			strings.TrimSuffix(b.String(), "\n"),
+			"",
+			d.help.ShortHelpView(keys.DetailHelp()),
+		),
+	)
+}
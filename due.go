@@ -0,0 +1,68 @@
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+type dueState int
+
+const (
+	notDue dueState = iota
+	dueLater
+	dueToday
+	overdue
+)
+
+func (t Task) dueState(now time.Time) dueState {
+	switch {
+	case t.due.IsZero():
+		return notDue
+	case t.due.Before(now):
+		return overdue
+	case sameDay(t.due, now):
+		return dueToday
+	}
+	return dueLater
+}
+
+func sameDay(a, b time.Time) bool {
+	ay, am, ad := a.Local().Date()
+	by, bm, bd := b.Local().Date()
+	return ay == by && am == bm && ad == bd
+}
+
+// formatDue renders the due date according to the dueFormat of the config.
+func formatDue(due, now time.Time, format string) string {
+	absolute := due.Local().Format("2006-01-02 15:04")
+	switch format {
+	case "absolute":
+		return absolute
+	case "both":
+		return fmt.Sprintf("%s (%s)", absolute, relativeDue(due, now))
+	}
+	return relativeDue(due, now)
+}
+
+// relativeDue renders the due date relative to now, e.g. "in 3d" or
+// "2h overdue".
+func relativeDue(due, now time.Time) string {
+	d := due.Sub(now)
+	if d < 0 {
+		return fmt.Sprintf("%s overdue", humanizeDuration(-d))
+	}
+	return fmt.Sprintf("in %s", humanizeDuration(d))
+}
+
+// humanizeDuration rounds the duration down to its largest unit.
+func humanizeDuration(d time.Duration) string {
+	switch {
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 48*time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	case d < 14*24*time.Hour:
+		return fmt.Sprintf("%dd", int(d.Hours()/24))
+	}
+	return fmt.Sprintf("%dw", int(d.Hours()/24/7))
+}
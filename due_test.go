@@ -0,0 +1,60 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+type dueTest struct {
+	name     string
+	format   string
+	expected string
+	due      time.Time
+}
+
+func TestFormatDue(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.Local)
+
+	tests := []dueTest{
+		{"Due in minutes", "relative", "in 30m", now.Add(30 * time.Minute)},
+		{"Due in hours", "relative", "in 26h", now.Add(26 * time.Hour)},
+		{"Due in days", "relative", "in 3d", now.Add(84 * time.Hour)},
+		{"Due in weeks", "relative", "in 5w", now.AddDate(0, 0, 36)},
+		{"Overdue", "relative", "2h overdue", now.Add(-2*time.Hour - 10*time.Minute)},
+		{"Absolute", "absolute", "2024-03-04 09:30", time.Date(2024, 3, 4, 9, 30, 0, 0, time.Local)},
+		{"Both", "both", "2024-03-04 12:00 (in 3d)", now.AddDate(0, 0, 3)},
+		{"Unknown format falls back to relative", "", "in 3d", now.AddDate(0, 0, 3)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := formatDue(tt.due, now, tt.format); result != tt.expected {
+				t.Errorf("formatDue(%v, %q) = %q, want %q", tt.due, tt.format, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDueState(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		name     string
+		due      time.Time
+		expected dueState
+	}{
+		{"No due date", time.Time{}, notDue},
+		{"Overdue", now.Add(-time.Minute), overdue},
+		{"Due today", time.Date(2024, 3, 1, 23, 59, 59, 0, time.Local), dueToday},
+		{"Due tomorrow", now.Add(24 * time.Hour), dueLater},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := Task{due: tt.due}
+			if result := task.dueState(now); result != tt.expected {
+				t.Errorf("dueState(%v) = %v, want %v", tt.due, result, tt.expected)
+			}
+		})
+	}
+}
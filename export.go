@@ -0,0 +1,337 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"html/template"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/DerTimonius/twkb/styles"
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// exportFormats are the formats the board can be exported to, they double as
+// the file extensions.
+var exportFormats = []string{"md", "csv", "json", "html"}
+
+// exportColumn is a column of the board as it gets exported.
+type exportColumn struct {
+	Title  string       `json:"title"`
+	Status string       `json:"status"`
+	Tasks  []exportTask `json:"tasks"`
+}
+
+type exportTask struct {
+	ID          int        `json:"id,omitempty"`
+	UUID        string     `json:"uuid"`
+	Description string     `json:"description"`
+	Project     string     `json:"project,omitempty"`
+	Tags        []string   `json:"tags,omitempty"`
+	Priority    string     `json:"priority,omitempty"`
+	Due         *time.Time `json:"due,omitempty"`
+	Urgency     float64    `json:"urgency"`
+}
+
+func newExportTask(t Task) exportTask {
+	e := exportTask{
+		ID:          t.id,
+		UUID:        t.uuid,
+		Description: t.description,
+		Project:     t.project,
+		Tags:        t.tags,
+		Priority:    t.priority,
+		Urgency:     t.urgency,
+	}
+	if !t.due.IsZero() {
+		due := t.due.Local()
+		e.Due = &due
+	}
+	return e
+}
+
+// exportColumns returns the columns of the current view with the tasks shown
+// on the board, in their order. In swimlane mode the lanes are merged.
+func (m *Board) exportColumns() []exportColumn {
+	cols := make([]exportColumn, len(m.statuses))
+	for i, s := range m.statuses {
+		cols[i] = exportColumn{Title: s.title(), Status: s.String(), Tasks: []exportTask{}}
+	}
+	for _, c := range m.allColumns() {
+		i := slices.Index(m.statuses, c.status)
+		if i == -1 {
+			continue
+		}
+		for _, item := range c.list.Items() {
+			cols[i].Tasks = append(cols[i].Tasks, newExportTask(item.(Task)))
+		}
+	}
+	return cols
+}
+
+// exportTitle describes what is exported, like the header of the board.
+func (m *Board) exportTitle() string {
+	title := "twkb"
+	if m.view != "" {
+		title += fmt.Sprintf(" • view: %s", m.view)
+	}
+	if m.context != "" {
+		title += fmt.Sprintf(" • context: %s", m.context)
+	}
+	if m.project != "" {
+		title += fmt.Sprintf(" • project: %s", m.project)
+	}
+	if q := m.search.query(); q != "" {
+		title += fmt.Sprintf(" • search: %s", q)
+	}
+	return title
+}
+
+// formatOf returns the export format for the extension of the path.
+func formatOf(path string) (string, error) {
+	ext := strings.TrimPrefix(filepath.Ext(path), ".")
+	if !slices.Contains(exportFormats, ext) {
+		return "", fmt.Errorf("unknown format %q, use .%s", ext, strings.Join(exportFormats, ", ."))
+	}
+	return ext, nil
+}
+
+func formatExportDue(due *time.Time) string {
+	if due == nil {
+		return ""
+	}
+	return due.Format("2006-01-02 15:04")
+}
+
+// writeExport writes the columns in the format.
+func writeExport(w io.Writer, format, title string, cols []exportColumn) error {
+	switch format {
+	case "md":
+		return writeMarkdown(w, title, cols)
+	case "csv":
+		return writeCSV(w, cols)
+	case "json":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(cols)
+	case "html":
+		return htmlExport.Execute(w, struct {
+			Title   string
+			Columns []exportColumn
+		}{title, cols})
+	}
+	return fmt.Errorf("unknown format %q, use %s", format, strings.Join(exportFormats, ", "))
+}
+
+func writeMarkdown(w io.Writer, title string, cols []exportColumn) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n", title)
+	for _, c := range cols {
+		fmt.Fprintf(&b, "\n## %s (%d)\n", c.Title, len(c.Tasks))
+		if len(c.Tasks) > 0 {
+			b.WriteString("\n")
+		}
+		check := " "
+		if c.Status == done.String() {
+			check = "x"
+		}
+		for _, t := range c.Tasks {
+			fmt.Fprintf(&b, "- [%s] %s", check, t.Description)
+			if t.Project != "" {
+				fmt.Fprintf(&b, " `%s`", t.Project)
+			}
+			for _, tag := range t.Tags {
+				fmt.Fprintf(&b, " #%s", tag)
+			}
+			if t.Priority != "" {
+				fmt.Fprintf(&b, " !%s", t.Priority)
+			}
+			if t.Due != nil {
+				fmt.Fprintf(&b, " (due %s)", formatExportDue(t.Due))
+			}
+			b.WriteString("\n")
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func writeCSV(w io.Writer, cols []exportColumn) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"column", "id", "uuid", "description", "project", "tags", "priority", "due", "urgency"})
+	for _, c := range cols {
+		for _, t := range c.Tasks {
+			cw.Write([]string{
+				c.Status, fmt.Sprint(t.ID), t.UUID, t.Description, t.Project,
+				strings.Join(t.Tags, " "), t.Priority, formatExportDue(t.Due), fmt.Sprintf("%.1f", t.Urgency),
+			})
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+var htmlExport = template.Must(template.New("board").Funcs(template.FuncMap{"due": formatExportDue}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { font-family: sans-serif; background: #1e1e2e; color: #cdd6f4; }
+main { display: flex; gap: 1em; align-items: flex-start; }
+section { flex: 1; background: #313244; border-radius: 6px; padding: 0 .75em; }
+article { background: #45475a; border-radius: 4px; margin: .75em 0; padding: .5em; }
+.project { background: #cba6f7; color: #45475a; padding: 0 .3em; }
+.tag { color: #74c7ec; }
+.due { color: #f9e2af; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<main>
+{{- range .Columns}}
+<section>
+<h2>{{.Title}} ({{len .Tasks}})</h2>
+{{- range .Tasks}}
+<article>
+<div>{{.Description}}</div>
+<div>{{with .Project}}<span class="project">{{.}}</span> {{end}}{{range .Tags}}<span class="tag">#{{.}}</span> {{end}}{{with .Priority}}!{{.}} {{end}}{{with .Due}}<span class="due">due {{due .}}</span>{{end}}</div>
+</article>
+{{- end}}
+</section>
+{{- end}}
+</main>
+</body>
+</html>
+`))
+
+// runExport is the `twkb export` subcommand, it writes the board to stdout or
+// a file without starting the TUI.
+func runExport(tw Backend, args []string, defaultView string) error {
+	fs := flag.NewFlagSet("export", flag.ExitOnError)
+	format := fs.String("format", "md", "export `format`, one of "+strings.Join(exportFormats, ", "))
+	output := fs.String("output", "", "write to `file` instead of stdout")
+	view := fs.String("view", defaultView, "export the named `view` of the config")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: twkb export [--format md|csv|json|html] [--output file] [--view name] [filter]")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	if !slices.Contains(exportFormats, *format) {
+		return fmt.Errorf("unknown format %q, use %s", *format, strings.Join(exportFormats, ", "))
+	}
+
+	b, err := loadBoard(tw, *view, strings.Join(fs.Args(), " "))
+	if err != nil {
+		return err
+	}
+
+	w := io.Writer(os.Stdout)
+	if *output != "" {
+		f, err := os.Create(*output)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+	return writeExport(w, *format, b.exportTitle(), b.exportColumns())
+}
+
+// exportMsg is the result of the export form.
+type exportMsg struct {
+	path string
+}
+
+// ExportForm asks for the file the current view of the board is written to,
+// the extension selects the format.
+type ExportForm struct {
+	help  help.Model
+	input textinput.Model
+	err   error
+}
+
+func NewExportForm() *ExportForm {
+	input := textinput.New()
+	input.SetValue(fmt.Sprintf("twkb-%s.md", time.Now().Format("2006-01-02")))
+	input.Width = 45
+	input.Focus()
+	return &ExportForm{help: help.New(), input: input}
+}
+
+func (e ExportForm) Init() tea.Cmd {
+	return nil
+}
+
+func (e ExportForm) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, keys.Back):
+			return e, closeView
+		case key.Matches(msg, keys.Submit):
+			if e.path() == "" {
+				e.err = errors.New("enter a file name")
+			} else {
+				_, e.err = formatOf(e.path())
+			}
+			if e.err != nil {
+				return e, nil
+			}
+			return e, submit(exportMsg{path: e.path()})
+		case msg.Type == tea.KeyCtrlC:
+			return e, tea.Quit
+		}
+	}
+	e.input, cmd = e.input.Update(msg)
+	e.err = nil
+	return e, cmd
+}
+
+func (e ExportForm) path() string {
+	return strings.TrimSpace(e.input.Value())
+}
+
+func (e ExportForm) View() string {
+	rows := []string{
+		styles.TitleStyle.Render("Export the board"),
+		styles.InputStyle.Render("File:        " + e.input.View()),
+	}
+	if e.err != nil {
+		rows = append(rows, styles.FieldErrorStyle.Render(e.err.Error()))
+	} else {
+		rows = append(rows, styles.PreviewStyle.Render("the extension selects the format: ."+strings.Join(exportFormats, ", .")))
+	}
+	rows = append(rows, strings.Repeat("─", 63), e.help.ShortHelpView(keys.QuickAddHelp()))
+	return styles.FormStyle.Render(lipgloss.JoinVertical(lipgloss.Left, rows...))
+}
+
+// export writes the current view of the board to the file.
+func (m *Board) export(path string) tea.Cmd {
+	format, err := formatOf(path)
+	if err != nil {
+		return notify(err.Error())
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return notify(err.Error())
+	}
+	defer f.Close()
+	if err := writeExport(f, format, m.exportTitle(), m.exportColumns()); err != nil {
+		return notify(err.Error())
+	}
+	return notify("exported to " + path)
+}
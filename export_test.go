@@ -0,0 +1,115 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func exportTestColumns() []exportColumn {
+	due := time.Date(2024, 3, 1, 14, 30, 0, 0, time.Local)
+	return []exportColumn{
+		{Title: "To Do", Status: "todo", Tasks: []exportTask{
+			{ID: 1, UUID: "a1", Description: "Write docs", Project: "work", Tags: []string{"docs", "urgent"}, Priority: "H", Due: &due, Urgency: 12.5},
+		}},
+		{Title: "In Progress", Status: "doing", Tasks: []exportTask{}},
+		{Title: "Done", Status: "done", Tasks: []exportTask{
+			{UUID: "b2", Description: `Fix "quotes", <tags>`},
+		}},
+	}
+}
+
+func TestWriteExport(t *testing.T) {
+	tests := []struct {
+		format   string
+		expected string
+	}{
+		{"md", `# twkb
+
+## To Do (1)
+
+- [ ] Write docs ` + "`work`" + ` #docs #urgent !H (due 2024-03-01 14:30)
+
+## In Progress (0)
+
+## Done (1)
+
+- [x] Fix "quotes", <tags>
+`},
+		{"csv", `column,id,uuid,description,project,tags,priority,due,urgency
+todo,1,a1,Write docs,work,docs urgent,H,2024-03-01 14:30,12.5
+done,0,b2,"Fix ""quotes"", <tags>",,,,,0.0
+`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			var b bytes.Buffer
+			if err := writeExport(&b, tt.format, "twkb", exportTestColumns()); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if b.String() != tt.expected {
+				t.Errorf("writeExport(%s) = %q, want %q", tt.format, b.String(), tt.expected)
+			}
+		})
+	}
+
+	var b bytes.Buffer
+	if err := writeExport(&b, "json", "twkb", exportTestColumns()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var cols []exportColumn
+	if err := json.Unmarshal(b.Bytes(), &cols); err != nil {
+		t.Fatalf("invalid json: %v", err)
+	}
+	if len(cols) != 3 || cols[0].Tasks[0].Project != "work" || cols[1].Tasks == nil || cols[2].Tasks[0].Due != nil {
+		t.Errorf("Unexpected json export %s", b.String())
+	}
+
+	b.Reset()
+	if err := writeExport(&b, "html", "twkb", exportTestColumns()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(b.String(), "Fix &#34;quotes&#34;, &lt;tags&gt;") || !strings.Contains(b.String(), "<h2>To Do (1)</h2>") {
+		t.Errorf("Expected escaped columns and tasks in the html export, got %s", b.String())
+	}
+
+	if err := writeExport(&b, "pdf", "twkb", nil); err == nil {
+		t.Error("expected an error for an unknown format")
+	}
+}
+
+func TestExportColumns(t *testing.T) {
+	config = defaultConfig()
+	b := NewBoard(newFakeBackend())
+	b.build([]Task{
+		{uuid: "1", description: "a", project: "work", status: todo, urgency: 1},
+		{uuid: "2", description: "b", project: "home", status: todo, urgency: 5},
+		{uuid: "3", description: "c", project: "work.web", status: done},
+		{uuid: "4", description: "d", status: never},
+	})
+	b.project = "work"
+	b.filterColumns()
+
+	cols := b.exportColumns()
+	if len(cols) != 3 {
+		t.Fatalf("Expected a column per status of the board, got %d", len(cols))
+	}
+	if len(cols[0].Tasks) != 1 || cols[0].Tasks[0].UUID != "1" || len(cols[2].Tasks) != 1 {
+		t.Errorf("Expected only the tasks shown on the board, got %+v", cols)
+	}
+	if title := b.exportTitle(); title != "twkb • project: work" {
+		t.Errorf("exportTitle() = %q", title)
+	}
+}
+
+func TestFormatOf(t *testing.T) {
+	if f, err := formatOf("board.html"); err != nil || f != "html" {
+		t.Errorf("formatOf(board.html) = %q, %v", f, err)
+	}
+	if _, err := formatOf("board.txt"); err == nil {
+		t.Error("expected an error for an unknown extension")
+	}
+}
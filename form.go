@@ -1,11 +1,11 @@
 package main
 
 import (
-	"bytes"
+	"errors"
 	"fmt"
 	"os"
-	"os/exec"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -26,17 +26,33 @@ type TaskForm struct {
 	due         textinput.Model
 	recur       textinput.Model
 	until       textinput.Model
+	wait        textinput.Model
+	scheduled   textinput.Model
+	priority    textinput.Model
 	col         column
 	relatedTask Task
 	index       int
 	isEdit      bool
+	// showErrors is set after the first attempt to submit an invalid form,
+	// until then only errors of fields with a value are shown
+	showErrors bool
+
+	// completions and dates are keyed by the name of the field
+	completions map[string]*completion
+	dates       map[string]dateCalc
+	// tw resolves the dates and suggestions
+	tw Backend
 }
 
+// dateFields are the fields holding a date expression that gets resolved
+// with `task calc`.
+var dateFields = []string{"due", "until", "wait", "scheduled"}
+
 func newDefaultForm() *TaskForm {
-	return NewForm("task name", "project (no spaces)", "labels (space separted list)", "due (e.g. eod, 2d)", "recur (e.g. monthly)", "until (e.g. now+1yr)")
+	return NewForm("task name", "project (no spaces)", "labels (space separted list)", "due (e.g. eod, 2d)", "recur (e.g. monthly)", "until (e.g. now+1yr)", "wait (e.g. monday)", "scheduled (e.g. tomorrow)", "priority (H, M or L)")
 }
 
-func NewForm(description, project, label, due, recur, until string) *TaskForm {
+func NewForm(description, project, label, due, recur, until, wait, scheduled, priority string) *TaskForm {
 	form := TaskForm{
 		help:        help.New(),
 		description: textinput.New(),
@@ -45,6 +61,11 @@ func NewForm(description, project, label, due, recur, until string) *TaskForm {
 		due:         textinput.New(),
 		recur:       textinput.New(),
 		until:       textinput.New(),
+		wait:        textinput.New(),
+		scheduled:   textinput.New(),
+		priority:    textinput.New(),
+		completions: map[string]*completion{},
+		dates:       map[string]dateCalc{},
 	}
 	form.description.Placeholder = description
 	form.project.Placeholder = project
@@ -52,57 +73,120 @@ func NewForm(description, project, label, due, recur, until string) *TaskForm {
 	form.due.Placeholder = due
 	form.recur.Placeholder = recur
 	form.until.Placeholder = until
+	form.wait.Placeholder = wait
+	form.scheduled.Placeholder = scheduled
+	form.priority.Placeholder = priority
 	form.description.Focus()
 	return &form
 }
 
-func (f TaskForm) CreateTask() Task {
-	cmdStr, err := AddCmd(f)
-	if err != nil {
-		fmt.Println(err)
-		os.Exit(1)
-	}
+// taskSavedMsg is the result of the task form and quick add. The command adds
+// or modifies the task, the task has the values of the form.
+type taskSavedMsg struct {
+	task  Task
+	index int
+	edit  bool
+	cmd   []string
+}
 
-	cmd := exec.Command(cmdStr[0], cmdStr[1:]...)
-	var out bytes.Buffer
-	cmd.Stdout = &out
-	err = cmd.Run()
-	if err != nil {
-		fmt.Println(err)
-		os.Exit(1)
+// saved returns the result of the form, errNothingToModify if an edit doesn't
+// change anything.
+func (f TaskForm) saved() (taskSavedMsg, error) {
+	if !f.isEdit {
+		cmdStr, err := AddCmd(f)
+		task := Task{status: todo, description: f.description.Value(), project: f.project.Value(), tags: strings.Fields(f.label.Value())}
+		return taskSavedMsg{task: task, index: f.index, cmd: cmdStr}, err
 	}
 
-	// get the id from the output of task
-	output := out.String()
-	re := regexp.MustCompile(`\d+`)
-	matchId := re.FindString(output)
-	id, e := strconv.Atoi(matchId)
-	if e != nil {
-		fmt.Println(e)
-		os.Exit(1)
+	t := f.relatedTask
+	cmdStr, err := ModifyCmd(t, &f)
+	if f.description.Value() != "" {
+		t.description = f.description.Value()
 	}
+	t.project = f.project.Value()
+	t.recur = f.recur.Value()
+	t.priority = f.priority.Value()
+	// the form resolved the date expressions already
+	t.due = f.dates["due"].date
+	t.until = f.dates["until"].date
+	t.wait = f.dates["wait"].date
+	t.scheduled = f.dates["scheduled"].date
+	t.tags = uniqueTags(strings.Fields(f.label.Value()))
+	return taskSavedMsg{task: t, index: f.index, edit: true, cmd: cmdStr}, err
+}
 
-	task := Task{id: id, status: todo, description: f.description.Value(), project: f.project.Value(), tags: strings.Split(f.label.Value(), " ")}
-	task.UpdateUrgency()
-	return task
+// extractId gets the id from the output of `task add`.
+func extractId(output string) (int, error) {
+	re := regexp.MustCompile(`\d+`)
+	return strconv.Atoi(re.FindString(output))
 }
 
 func NewEditForm(t Task) *TaskForm {
-	form := TaskForm{
-		help:        help.New(),
-		description: textinput.New(),
-		project:     textinput.New(),
-		label:       textinput.New(),
-		due:         textinput.New(),
-		isEdit:      true,
-		relatedTask: t,
-	}
+	form := newDefaultForm()
+	form.isEdit = true
+	form.relatedTask = t
 	form.description.SetValue(t.description)
 	form.project.SetValue(t.project)
 	form.label.SetValue(strings.Join(t.tags, " "))
-	form.due.SetValue(t.due)
-	form.description.Focus()
-	return &form
+	form.due.SetValue(formatTWDate(t.due))
+	form.recur.SetValue(t.recur)
+	form.until.SetValue(formatTWDate(t.until))
+	form.wait.SetValue(formatTWDate(t.wait))
+	form.scheduled.SetValue(formatTWDate(t.scheduled))
+	form.priority.SetValue(t.priority)
+	return form
+}
+
+// loadCompletions fills the suggestions of the project, label, priority and
+// date fields from taskwarrior and the tasks already loaded on the board.
+func (f *TaskForm) loadCompletions(tw Backend, tasks []Task) {
+	f.tw = tw
+	projects := getCompletionWords(tw, ProjectsCmd())
+	tags := getCompletionWords(tw, TagsCmd())
+	for _, t := range tasks {
+		if t.project != "" {
+			projects = append(projects, t.project)
+		}
+		tags = append(tags, t.tags...)
+	}
+
+	f.completions["project"] = newCompletion(projects, false)
+	f.completions["label"] = newCompletion(tags, true)
+	f.completions["priority"] = newCompletion([]string{"H", "M", "L"}, false)
+	for _, name := range dateFields {
+		f.completions[name] = newCompletion(slices.Clone(dateSynonyms), false)
+	}
+}
+
+// inputs returns the names and inputs of the form in the order they are shown.
+func (f *TaskForm) inputs() ([]string, []*textinput.Model) {
+	return []string{"description", "project", "label", "due", "recur", "until", "wait", "scheduled", "priority"},
+		[]*textinput.Model{&f.description, &f.project, &f.label, &f.due, &f.recur, &f.until, &f.wait, &f.scheduled, &f.priority}
+}
+
+// input returns the input with the given name.
+func (f *TaskForm) input(name string) *textinput.Model {
+	names, inputs := f.inputs()
+	return inputs[slices.Index(names, name)]
+}
+
+// focusedInput returns the name and the input that currently has the focus.
+func (f *TaskForm) focusedInput() (string, *textinput.Model) {
+	names, inputs := f.inputs()
+	for i, input := range inputs {
+		if input.Focused() {
+			return names[i], input
+		}
+	}
+	return names[0], inputs[0]
+}
+
+// focusNext moves the focus to the next input, wrapping around at the end.
+func (f *TaskForm) focusNext() {
+	names, inputs := f.inputs()
+	name, input := f.focusedInput()
+	input.Blur()
+	inputs[(slices.Index(names, name)+1)%len(inputs)].Focus()
 }
 
 func (f TaskForm) Init() tea.Cmd {
@@ -115,93 +199,130 @@ func (f TaskForm) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case column:
 		f.col = msg
 		f.col.list.Index()
+	case dateCalc:
+		// ignore results for expressions that have been changed in the meantime
+		if msg.expr == f.input(msg.field).Value() {
+			f.dates[msg.field] = msg
+		}
+		return f, nil
 	case tea.KeyMsg:
+		name, input := f.focusedInput()
+		c := f.completions[name]
+		switch {
+		case key.Matches(msg, keys.NextSuggestion) && c != nil:
+			c.next()
+			return f, nil
+		case key.Matches(msg, keys.PrevSuggestion) && c != nil:
+			c.prev()
+			return f, nil
+		case key.Matches(msg, keys.Tab) && c != nil && len(c.matches) > 0:
+			value, _ := c.accept(input.Value())
+			input.SetValue(value)
+			input.CursorEnd()
+			c.update(value)
+			return f, f.resolveDates()
+		}
+
 		switch {
 		case key.Matches(msg, keys.Quit):
 			return f, tea.Quit
 
 		case key.Matches(msg, keys.Back):
-			return board.Update(nil)
+			return f, closeView
 		case key.Matches(msg, keys.Enter):
-			return board.Update(f)
-		case key.Matches(msg, keys.Tab):
-			if f.description.Focused() {
-				f.description.Blur()
-				f.project.Focus()
-				return f, textarea.Blink
-			}
-			if f.project.Focused() {
-				f.project.Blur()
-				f.label.Focus()
-				return f, textarea.Blink
+			if !f.valid() {
+				f.showErrors = true
+				return f, nil
 			}
-			if f.label.Focused() {
-				f.label.Blur()
-				f.due.Focus()
-				return f, textarea.Blink
+			saved, err := f.saved()
+			if errors.Is(err, errNothingToModify) {
+				return f, closeView
 			}
-			if f.due.Focused() {
-				f.due.Blur()
-				f.recur.Focus()
-				return f, textarea.Blink
-			}
-			if f.recur.Focused() {
-				f.recur.Blur()
-				f.until.Focus()
-				return f, textarea.Blink
-			}
-			if f.until.Focused() {
-				f.until.Blur()
-				f.description.Focus()
-				return f, textarea.Blink
+			if err != nil {
+				fmt.Println(err)
+				os.Exit(1)
 			}
+			return f, submit(saved)
+		case key.Matches(msg, keys.Tab):
+			f.focusNext()
+			return f, textarea.Blink
 		}
 	}
-	if f.description.Focused() {
-		f.description, cmd = f.description.Update(msg)
-		return f, cmd
+	name, input := f.focusedInput()
+	*input, cmd = input.Update(msg)
+	if c := f.completions[name]; c != nil {
+		c.update(input.Value())
 	}
-	if f.project.Focused() {
-		f.project, cmd = f.project.Update(msg)
-		return f, cmd
+	return f, tea.Batch(cmd, f.resolveDates())
+}
+
+// resolveDates starts resolving the date fields whose expressions have
+// changed since the last run.
+func (f *TaskForm) resolveDates() tea.Cmd {
+	var cmds []tea.Cmd
+	for _, name := range dateFields {
+		value := f.input(name).Value()
+		if value == f.dates[name].expr {
+			continue
+		}
+		f.dates[name] = dateCalc{field: name, expr: value}
+		if value != "" {
+			cmds = append(cmds, calcDate(f.tw, name, value))
+		}
 	}
-	if f.label.Focused() {
-		f.label, cmd = f.label.Update(msg)
-		return f, cmd
+	return tea.Batch(cmds...)
+}
+
+// datePreview shows the absolute date a date expression resolves to. Errors
+// are shown by the validation.
+func datePreview(d dateCalc) string {
+	switch {
+	case d.expr == "", d.err != nil:
+		return ""
+	case d.date.IsZero():
+		return styles.DatePreviewStyle.Render("resolving...")
 	}
-	if f.recur.Focused() {
-		f.recur, cmd = f.recur.Update(msg)
-		return f, cmd
+	return styles.DatePreviewStyle.Render("→ " + d.date.Format("Mon, 2006-01-02 15:04"))
+}
+
+// field renders a labelled input, followed by the suggestions if the input is
+// focused, the resolved date for date fields and the validation error.
+func (f TaskForm) field(label, name string, err error) string {
+	input := f.input(name)
+	rows := []string{styles.InputStyle.Render(label + input.View())}
+	if c := f.completions[name]; input.Focused() && c.View() != "" {
+		rows = append(rows, styles.SuggestionsStyle.Render(c.View()))
+	}
+	if slices.Contains(dateFields, name) && datePreview(f.dates[name]) != "" {
+		rows = append(rows, datePreview(f.dates[name]))
 	}
-	if f.until.Focused() {
-		f.until, cmd = f.until.Update(msg)
-		return f, cmd
+	if err != nil && (input.Value() != "" || f.showErrors) {
+		rows = append(rows, styles.FieldErrorStyle.Render(err.Error()))
 	}
-	f.due, cmd = f.due.Update(msg)
-	return f, cmd
+	return styles.FieldStyle.Render(lipgloss.JoinVertical(lipgloss.Left, rows...))
 }
 
 func (f TaskForm) View() string {
 	title := styles.TitleStyle.Render("Create or update a Task")
 
-	fieldStyle := styles.FieldStyle
-	inputStyle := styles.InputStyle
+	errs, pending := f.validate()
 	inputs := lipgloss.JoinVertical(
 		lipgloss.Left,
-		fieldStyle.Render(inputStyle.Render("Description: "+f.description.View())),
-		fieldStyle.Render(inputStyle.Render("Project:     "+f.project.View())),
-		fieldStyle.Render(inputStyle.Render("Label:       "+f.label.View())),
-		fieldStyle.Render(inputStyle.Render("Due:         "+f.due.View())),
+		f.field("Description: ", "description", errs["description"]),
+		f.field("Project:     ", "project", errs["project"]),
+		f.field("Label:       ", "label", errs["label"]),
+		f.field("Due:         ", "due", errs["due"]),
+		f.field("Recur:       ", "recur", errs["recur"]),
+		f.field("Until:       ", "until", errs["until"]),
+		f.field("Wait:        ", "wait", errs["wait"]),
+		f.field("Scheduled:   ", "scheduled", errs["scheduled"]),
+		f.field("Priority:    ", "priority", errs["priority"]),
 	)
 
-	if !f.isEdit {
-		inputs = lipgloss.JoinVertical(lipgloss.Left, inputs,
-			fieldStyle.Render(inputStyle.Render("Recur:       "+f.recur.View())),
-			fieldStyle.Render(inputStyle.Render("Until:       "+f.until.View())),
-		)
-	}
-
 	help := f.help.View(keys)
+	if f.showErrors && (len(errs) > 0 || pending) {
+		help = lipgloss.JoinVertical(lipgloss.Left, styles.ErrorStyle.Render("Fix the errors above to submit the task"), help)
+	}
 
 	return styles.FormStyle.Render(
 		lipgloss.JoinVertical(
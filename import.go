@@ -0,0 +1,416 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/rand"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// importFormats are the formats `twkb import` reads, by file extension.
+var importFormats = map[string]string{".csv": "csv", ".md": "md", ".markdown": "md", ".json": "json"}
+
+// importAttributes are the attributes a CSV column can be mapped to.
+var importAttributes = []string{"description", "project", "tags", "priority", "due", "scheduled", "wait", "status"}
+
+// importDates are the attributes holding a date, they get resolved before the
+// import as taskwarrior expects absolute dates.
+var importDates = []string{"due", "scheduled", "wait"}
+
+// checklistRe matches a markdown checklist item like `  - [x] item`.
+var checklistRe = regexp.MustCompile(`^(\s*)[-*+] \[([ xX])\] (.+)$`)
+
+// importer turns the files into tasks in the format of `task import`.
+type importer struct {
+	now     time.Time
+	newUUID func() string
+	resolve func(string) (time.Time, error)
+}
+
+func newImporter(tw Backend) importer {
+	resolve := func(expr string) (time.Time, error) { return resolveDate(tw, expr) }
+	return importer{now: time.Now(), newUUID: newUUID, resolve: resolve}
+}
+
+// newUUID returns a random UUID, the dependencies of imported tasks refer to
+// them before taskwarrior knows the tasks.
+func newUUID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+// newTask returns a pending task with the fields every import needs.
+func (im importer) newTask(description string) map[string]any {
+	return map[string]any{
+		"uuid":        im.newUUID(),
+		"description": description,
+		"status":      "pending",
+		"entry":       im.now.UTC().Format(twTimeFormat),
+	}
+}
+
+func (im importer) complete(task map[string]any) {
+	task["status"] = "completed"
+	task["end"] = im.now.UTC().Format(twTimeFormat)
+}
+
+// parseMarkdown reads the checklist items of a markdown file. Nested items
+// become dependencies of their parent, `#tag` adds a tag and `@project` sets
+// the project. Checked items are imported as done.
+func (im importer) parseMarkdown(r io.Reader) ([]map[string]any, error) {
+	type parent struct {
+		indent int
+		task   map[string]any
+	}
+	var tasks []map[string]any
+	var parents []parent
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		match := checklistRe.FindStringSubmatch(scanner.Text())
+		if match == nil {
+			continue
+		}
+		indent := len(strings.ReplaceAll(match[1], "\t", "    "))
+
+		var words, tags []string
+		var project string
+		for _, word := range strings.Fields(match[3]) {
+			switch {
+			case len(word) > 1 && word[0] == '#' && tagRe.MatchString(word[1:]):
+				tags = append(tags, word[1:])
+			case len(word) > 1 && word[0] == '@' && project == "" && validateProject(word[1:]) == nil:
+				project = word[1:]
+			default:
+				words = append(words, word)
+			}
+		}
+		if len(words) == 0 {
+			continue
+		}
+
+		task := im.newTask(strings.Join(words, " "))
+		if project != "" {
+			task["project"] = project
+		}
+		if len(tags) > 0 {
+			task["tags"] = tags
+		}
+		if match[2] != " " {
+			im.complete(task)
+		}
+
+		for len(parents) > 0 && parents[len(parents)-1].indent >= indent {
+			parents = parents[:len(parents)-1]
+		}
+		if len(parents) > 0 {
+			p := parents[len(parents)-1].task
+			depends, _ := p["depends"].([]string)
+			p["depends"] = append(depends, task["uuid"].(string))
+		}
+		parents = append(parents, parent{indent, task})
+		tasks = append(tasks, task)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(tasks) == 0 {
+		return nil, errors.New("no checklist items like `- [ ] item` found")
+	}
+	return tasks, nil
+}
+
+// parseMapping parses a CSV column mapping like `description=Title,tags=Labels`.
+func parseMapping(s string) (map[string]string, error) {
+	mapping := map[string]string{}
+	for _, pair := range strings.Split(s, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		attr, column, ok := strings.Cut(pair, "=")
+		attr = strings.TrimSpace(attr)
+		if !ok || !slices.Contains(importAttributes, attr) {
+			return nil, fmt.Errorf("invalid mapping %q, use attribute=column with one of %s", pair, strings.Join(importAttributes, ", "))
+		}
+		mapping[attr] = strings.TrimSpace(column)
+	}
+	return mapping, nil
+}
+
+// parseCSV reads a CSV file with a header. The columns named like an
+// attribute are imported, the mapping assigns other columns to attributes.
+func (im importer) parseCSV(r io.Reader, mapping map[string]string) ([]map[string]any, error) {
+	cr := csv.NewReader(r)
+	cr.TrimLeadingSpace = true
+	header, err := cr.Read()
+	if err != nil {
+		return nil, fmt.Errorf("could not read the header: %w", err)
+	}
+
+	columns := map[string]int{}
+	for _, attr := range importAttributes {
+		name, ok := mapping[attr]
+		if !ok {
+			name = attr
+		}
+		i := slices.IndexFunc(header, func(h string) bool { return strings.EqualFold(strings.TrimSpace(h), name) })
+		if i == -1 && ok {
+			return nil, fmt.Errorf("there is no column %q for %s", name, attr)
+		}
+		if i != -1 {
+			columns[attr] = i
+		}
+	}
+	if _, ok := columns["description"]; !ok {
+		return nil, errors.New("there is no description column, map one with --map description=<column>")
+	}
+
+	var tasks []map[string]any
+	for {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := cr.FieldPos(0)
+		value := func(attr string) string {
+			i, ok := columns[attr]
+			if !ok || i >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[i])
+		}
+
+		if value("description") == "" {
+			return nil, fmt.Errorf("line %d: the description is empty", line)
+		}
+		task := im.newTask(value("description"))
+		if project := value("project"); project != "" {
+			if err := validateProject(project); err != nil {
+				return nil, fmt.Errorf("line %d: %w", line, err)
+			}
+			task["project"] = project
+		}
+		if tags := strings.FieldsFunc(value("tags"), func(r rune) bool { return r == ',' || r == ' ' }); len(tags) > 0 {
+			if err := validateTags(strings.Join(tags, " ")); err != nil {
+				return nil, fmt.Errorf("line %d: %w", line, err)
+			}
+			task["tags"] = tags
+		}
+		if priority := value("priority"); priority != "" {
+			if err := validatePriority(priority); err != nil {
+				return nil, fmt.Errorf("line %d: %w", line, err)
+			}
+			task["priority"] = priority
+		}
+		for _, attr := range importDates {
+			if expr := value(attr); expr != "" {
+				date, err := im.resolve(expr)
+				if err != nil {
+					return nil, fmt.Errorf("line %d: %s %q is not a valid date", line, attr, expr)
+				}
+				task[attr] = date.UTC().Format(twTimeFormat)
+			}
+		}
+		switch strings.ToLower(value("status")) {
+		case "", "pending", "todo":
+		case "completed", "done", "x":
+			im.complete(task)
+		default:
+			return nil, fmt.Errorf("line %d: unknown status %q", line, value("status"))
+		}
+		tasks = append(tasks, task)
+	}
+	if len(tasks) == 0 {
+		return nil, errors.New("the file has no tasks")
+	}
+	return tasks, nil
+}
+
+// parseJSON reads taskwarrior JSON, either an array like `task export` or a
+// task per line. The tasks are imported as they are.
+func parseJSON(r io.Reader) ([]map[string]any, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var tasks []map[string]any
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '[' {
+		if err := json.Unmarshal(trimmed, &tasks); err != nil {
+			return nil, err
+		}
+	} else {
+		dec := json.NewDecoder(bytes.NewReader(data))
+		for {
+			var task map[string]any
+			if err := dec.Decode(&task); errors.Is(err, io.EOF) {
+				break
+			} else if err != nil {
+				return nil, err
+			}
+			tasks = append(tasks, task)
+		}
+	}
+
+	for i, task := range tasks {
+		if d, _ := task["description"].(string); d == "" {
+			return nil, fmt.Errorf("task %d has no description", i+1)
+		}
+	}
+	if len(tasks) == 0 {
+		return nil, errors.New("the file has no tasks")
+	}
+	return tasks, nil
+}
+
+// ImportCmd imports all tasks of the file with a single call.
+func ImportCmd(path string) []string {
+	return []string{"task", "rc.confirmation=no", "import", path}
+}
+
+// writeImportPreview lists the tasks that would be imported.
+func writeImportPreview(w io.Writer, tasks []map[string]any) error {
+	uuids := map[string]int{}
+	for i, task := range tasks {
+		if uuid, ok := task["uuid"].(string); ok {
+			uuids[uuid] = i + 1
+		}
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "#\tDESCRIPTION\tPROJECT\tTAGS\tDUE\tSTATUS\tDEPENDS ON")
+	for i, task := range tasks {
+		var tags []string
+		for _, tag := range asSlice(task["tags"]) {
+			tags = append(tags, "#"+tag)
+		}
+		var depends []string
+		for _, uuid := range asSlice(task["depends"]) {
+			if n, ok := uuids[uuid]; ok {
+				depends = append(depends, fmt.Sprint(n))
+			} else {
+				depends = append(depends, uuid)
+			}
+		}
+		due := ""
+		if d := parseTWDate(task["due"]); !d.IsZero() {
+			due = d.Local().Format("2006-01-02 15:04")
+		}
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\t%s\t%s\n", i+1, task["description"], str(task["project"]),
+			strings.Join(tags, " "), due, str(task["status"]), strings.Join(depends, ", "))
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintf(w, "\n%d tasks would be imported\n", len(tasks))
+	return err
+}
+
+// asSlice returns the strings of a list read from JSON or built by the
+// importer. Taskwarrior 2.5 writes the dependencies as a comma separated string.
+func asSlice(v any) []string {
+	switch v := v.(type) {
+	case []string:
+		return v
+	case []any:
+		var s []string
+		for _, item := range v {
+			s = append(s, fmt.Sprint(item))
+		}
+		return s
+	case string:
+		return strings.Split(v, ",")
+	}
+	return nil
+}
+
+func str(v any) string {
+	if v == nil {
+		return ""
+	}
+	return fmt.Sprint(v)
+}
+
+// runImport is the `twkb import` subcommand.
+func runImport(tw Backend, args []string) error {
+	fs := flag.NewFlagSet("import", flag.ExitOnError)
+	format := fs.String("format", "", "`format` of the file: csv, md or json, by default from the extension")
+	mapping := fs.String("map", "", "map CSV `columns` to attributes, e.g. description=Title,tags=Labels")
+	dryRun := fs.Bool("dry-run", false, "only show the tasks that would be imported")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: twkb import [--format csv|md|json] [--map attribute=column,...] [--dry-run] <file>")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return errors.New("import needs exactly one file")
+	}
+
+	path := fs.Arg(0)
+	if *format == "" {
+		*format = importFormats[strings.ToLower(filepath.Ext(path))]
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	im := newImporter(tw)
+	var tasks []map[string]any
+	switch *format {
+	case "md":
+		tasks, err = im.parseMarkdown(f)
+	case "csv":
+		var m map[string]string
+		if m, err = parseMapping(*mapping); err == nil {
+			tasks, err = im.parseCSV(f, m)
+		}
+	case "json":
+		tasks, err = parseJSON(f)
+	default:
+		return fmt.Errorf("unknown format of %s, use --format csv, md or json", path)
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+
+	if *dryRun {
+		return writeImportPreview(os.Stdout, tasks)
+	}
+
+	tmp, err := os.CreateTemp("", "twkb-import-*.json")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if err := json.NewEncoder(tmp).Encode(tasks); err != nil {
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	output, err := tw.Run(ImportCmd(tmp.Name()))
+	fmt.Print(output)
+	return err
+}
@@ -0,0 +1,194 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testImporter() importer {
+	n := 0
+	return importer{
+		now: time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC),
+		newUUID: func() string {
+			n++
+			return fmt.Sprintf("uuid-%d", n)
+		},
+		resolve: func(expr string) (time.Time, error) {
+			if expr == "tomorrow" {
+				return time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC), nil
+			}
+			return time.Time{}, errors.New("invalid date")
+		},
+	}
+}
+
+// importJSON encodes the tasks like they are passed to `task import`.
+func importJSON(t *testing.T, tasks []map[string]any) string {
+	t.Helper()
+	b, err := json.Marshal(tasks)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(b)
+}
+
+func TestParseMarkdown(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+		err      string
+	}{
+		{
+			input:    "# Release\n\n- [ ] Ship it @work.release #urgent\n  - [x] Write notes\n  - [ ] Tag #git\n    * [ ] Sign the tag\n- [ ] Celebrate\n\nsome text\n- not a task",
+			expected: `[{"depends":["uuid-2","uuid-3"],"description":"Ship it","entry":"20240301T120000Z","project":"work.release","status":"pending","tags":["urgent"],"uuid":"uuid-1"},{"description":"Write notes","end":"20240301T120000Z","entry":"20240301T120000Z","status":"completed","uuid":"uuid-2"},{"depends":["uuid-4"],"description":"Tag","entry":"20240301T120000Z","status":"pending","tags":["git"],"uuid":"uuid-3"},{"description":"Sign the tag","entry":"20240301T120000Z","status":"pending","uuid":"uuid-4"},{"description":"Celebrate","entry":"20240301T120000Z","status":"pending","uuid":"uuid-5"}]`,
+		},
+		{
+			input:    "- [ ] Mail bob@example.com @home @work",
+			expected: `[{"description":"Mail bob@example.com @work","entry":"20240301T120000Z","project":"home","status":"pending","uuid":"uuid-1"}]`,
+		},
+		{
+			input: "no checklist here\n- [ ] #only #tags",
+			err:   "no checklist items",
+		},
+	}
+
+	for i, test := range tests {
+		tasks, err := testImporter().parseMarkdown(strings.NewReader(test.input))
+		if test.err != "" {
+			if err == nil || !strings.Contains(err.Error(), test.err) {
+				t.Errorf("Test %d: expected error %q, got %v", i+1, test.err, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Test %d: unexpected error: %v", i+1, err)
+			continue
+		}
+		if result := importJSON(t, tasks); result != test.expected {
+			t.Errorf("Test %d: expected %s, got %s", i+1, test.expected, result)
+		}
+	}
+}
+
+func TestParseCSV(t *testing.T) {
+	tests := []struct {
+		input    string
+		mapping  string
+		expected string
+		err      string
+	}{
+		{
+			input:    "Description,Project,Tags,Due,Status\nWrite docs,work,\"docs, urgent\",tomorrow,\nOld task,,,,done\n",
+			expected: `[{"description":"Write docs","due":"20240302T000000Z","entry":"20240301T120000Z","project":"work","status":"pending","tags":["docs","urgent"],"uuid":"uuid-1"},{"description":"Old task","end":"20240301T120000Z","entry":"20240301T120000Z","status":"completed","uuid":"uuid-2"}]`,
+		},
+		{
+			input:    "Title,Labels,Prio\nPlan,home garden,H\n",
+			mapping:  "description=Title, tags=Labels,priority=Prio",
+			expected: `[{"description":"Plan","entry":"20240301T120000Z","priority":"H","status":"pending","tags":["home","garden"],"uuid":"uuid-1"}]`,
+		},
+		{
+			input: "Title\nPlan\n",
+			err:   "no description column",
+		},
+		{
+			input:   "Title\nPlan\n",
+			mapping: "description=Name",
+			err:     `no column "Name"`,
+		},
+		{
+			input: "description,due\nPlan,someday\n",
+			err:   `line 2: due "someday" is not a valid date`,
+		},
+		{
+			input: "description\nPlan\n\"\"\n",
+			err:   "line 3: the description is empty",
+		},
+		{
+			input: "description,status\nPlan,waiting\n",
+			err:   `line 2: unknown status "waiting"`,
+		},
+		{
+			input: "description,priority\nPlan,H\nShip,urgent\n",
+			err:   "line 3: priority has to be H, M or L",
+		},
+	}
+
+	for i, test := range tests {
+		mapping, err := parseMapping(test.mapping)
+		if err != nil {
+			t.Fatalf("Test %d: invalid mapping: %v", i+1, err)
+		}
+		tasks, err := testImporter().parseCSV(strings.NewReader(test.input), mapping)
+		if test.err != "" {
+			if err == nil || !strings.Contains(err.Error(), test.err) {
+				t.Errorf("Test %d: expected error %q, got %v", i+1, test.err, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Test %d: unexpected error: %v", i+1, err)
+			continue
+		}
+		if result := importJSON(t, tasks); result != test.expected {
+			t.Errorf("Test %d: expected %s, got %s", i+1, test.expected, result)
+		}
+	}
+}
+
+func TestParseMapping(t *testing.T) {
+	if _, err := parseMapping("title=Name"); err == nil {
+		t.Error("expected an error for an unknown attribute")
+	}
+	if _, err := parseMapping("description"); err == nil {
+		t.Error("expected an error for a mapping without column")
+	}
+}
+
+func TestParseJSON(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int
+		err      bool
+	}{
+		{`[{"description":"a"},{"description":"b","status":"completed"}]`, 2, false},
+		{"{\"description\":\"a\"}\n{\"description\":\"b\"}\n", 2, false},
+		{`[{"description":"a"},{"project":"work"}]`, 0, true},
+		{"", 0, true},
+		{"[{", 0, true},
+	}
+
+	for i, test := range tests {
+		tasks, err := parseJSON(strings.NewReader(test.input))
+		if test.err != (err != nil) {
+			t.Errorf("Test %d: expected error %v, got %v", i+1, test.err, err)
+		}
+		if len(tasks) != test.expected {
+			t.Errorf("Test %d: expected %d tasks, got %d", i+1, test.expected, len(tasks))
+		}
+	}
+}
+
+func TestWriteImportPreview(t *testing.T) {
+	tasks, err := testImporter().parseMarkdown(strings.NewReader("- [ ] Ship it @work #urgent\n  - [x] Write notes"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var b bytes.Buffer
+	if err := writeImportPreview(&b, tasks); err != nil {
+		t.Fatal(err)
+	}
+	expected := `#  DESCRIPTION  PROJECT  TAGS     DUE  STATUS     DEPENDS ON
+1  Ship it      work     #urgent       pending    2
+2  Write notes                         completed  
+
+2 tasks would be imported
+`
+	if b.String() != expected {
+		t.Errorf("expected\n%s\ngot\n%s", expected, b.String())
+	}
+}
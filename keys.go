@@ -5,7 +5,7 @@ import "github.com/charmbracelet/bubbles/key"
 // ShortHelp returns keybindings to be shown in the mini help view. It's part
 // of the key.Map interface.
 func (k keyMap) ShortHelp() []key.Binding {
-	return []key.Binding{k.Tab, k.Submit, k.Back, k.Help, k.Quit}
+	return []key.Binding{k.Tab, k.NextSuggestion, k.Submit, k.Back, k.Help, k.Quit}
 }
 
 // FullHelp returns keybindings for the expanded help view. It's part of the
@@ -15,8 +15,14 @@ func (k keyMap) FullHelp() [][]key.Binding {
 		{k.Up, k.Down},
 		{k.Left, k.Right},
 		{k.Space, k.Enter},
-		{k.New, k.Edit},
+		{k.New, k.QuickAdd, k.Edit, k.Info},
 		{k.Block, k.Unblock},
+		{k.ToggleTags, k.AddTag, k.RemoveTag},
+		{k.Projects, k.RenameProject},
+		{k.TimeSummary, k.Stats, k.FlowReport},
+		{k.Context, k.View, k.Compact, k.Export},
+		{k.Swimlanes, k.NextLane, k.PrevLane},
+		{k.MoveLaneDown, k.MoveLaneUp, k.Collapse},
 		{k.Filter, k.Quit},
 	}
 }
@@ -25,9 +31,38 @@ func (k keyMap) BlockHelp() []key.Binding {
 	return []key.Binding{k.Up, k.Down, k.BlockSelect, k.BlockSubmit, k.Back}
 }
 
+func (k keyMap) PickerHelp() []key.Binding {
+	return []key.Binding{k.Up, k.Down, k.BlockSubmit, k.Back}
+}
+
+func (k keyMap) TagHelp() []key.Binding {
+	return []key.Binding{k.Up, k.Down, k.TagSelect, k.BlockSubmit, k.Back}
+}
+
+func (k keyMap) TagInputHelp() []key.Binding {
+	return []key.Binding{k.Tab, k.NextSuggestion, k.BlockSubmit, k.Back}
+}
+
+func (k keyMap) SidebarHelp() []key.Binding {
+	return []key.Binding{k.Up, k.Down, k.BlockSubmit, k.RenameProject, k.Back}
+}
+
+func (k keyMap) DetailHelp() []key.Binding {
+	return []key.Binding{k.Info, k.Back}
+}
+
+func (k keyMap) FlowReportHelp() []key.Binding {
+	return []key.Binding{k.Left, k.Right, k.Back}
+}
+
+func (k keyMap) QuickAddHelp() []key.Binding {
+	return []key.Binding{k.BlockSubmit, k.Back}
+}
+
 type keyMap struct {
 	New         key.Binding
 	Edit        key.Binding
+	Info        key.Binding
 	Delete      key.Binding
 	Up          key.Binding
 	Down        key.Binding
@@ -47,6 +82,31 @@ type keyMap struct {
 	Block       key.Binding
 	BlockSelect key.Binding
 	BlockSubmit key.Binding
+	TimeSummary key.Binding
+	Stats       key.Binding
+	FlowReport  key.Binding
+	Export      key.Binding
+	Context     key.Binding
+	View        key.Binding
+	QuickAdd    key.Binding
+	Compact     key.Binding
+	Swimlanes   key.Binding
+	ToggleTags  key.Binding
+	AddTag      key.Binding
+	RemoveTag   key.Binding
+	TagSelect   key.Binding
+
+	Projects      key.Binding
+	RenameProject key.Binding
+
+	NextLane     key.Binding
+	PrevLane     key.Binding
+	MoveLaneDown key.Binding
+	MoveLaneUp   key.Binding
+	Collapse     key.Binding
+
+	NextSuggestion key.Binding
+	PrevSuggestion key.Binding
 }
 
 var keys = keyMap{
@@ -58,6 +118,10 @@ var keys = keyMap{
 		key.WithKeys("m"),
 		key.WithHelp("m", "modify focused task"),
 	),
+	Info: key.NewBinding(
+		key.WithKeys("i"),
+		key.WithHelp("i", "task details"),
+	),
 	Delete: key.NewBinding(
 		key.WithKeys("d"),
 		key.WithHelp("d", "delete task"),
@@ -108,7 +172,7 @@ var keys = keyMap{
 	),
 	Filter: key.NewBinding(
 		key.WithKeys("/"),
-		key.WithHelp("/", "filter tasks"),
+		key.WithHelp("/", "search all columns"),
 	),
 	No: key.NewBinding(
 		key.WithKeys("n"),
@@ -134,4 +198,92 @@ var keys = keyMap{
 		key.WithKeys("enter"),
 		key.WithHelp("enter", "submit"),
 	),
+	TimeSummary: key.NewBinding(
+		key.WithKeys("t"),
+		key.WithHelp("t", "tracked time"),
+	),
+	Stats: key.NewBinding(
+		key.WithKeys("S"),
+		key.WithHelp("S", "statistics"),
+	),
+	FlowReport: key.NewBinding(
+		key.WithKeys("L"),
+		key.WithHelp("L", "lead/cycle time"),
+	),
+	Export: key.NewBinding(
+		key.WithKeys("E"),
+		key.WithHelp("E", "export board"),
+	),
+	Context: key.NewBinding(
+		key.WithKeys("c"),
+		key.WithHelp("c", "switch context"),
+	),
+	View: key.NewBinding(
+		key.WithKeys("w"),
+		key.WithHelp("w", "switch view"),
+	),
+	QuickAdd: key.NewBinding(
+		key.WithKeys("a", ":"),
+		key.WithHelp("a/:", "quick add task"),
+	),
+	Compact: key.NewBinding(
+		key.WithKeys("v"),
+		key.WithHelp("v", "compact/expanded cards"),
+	),
+	Swimlanes: key.NewBinding(
+		key.WithKeys("s"),
+		key.WithHelp("s", "switch swimlanes"),
+	),
+	NextLane: key.NewBinding(
+		key.WithKeys("J", "shift+down"),
+		key.WithHelp("J", "next lane"),
+	),
+	PrevLane: key.NewBinding(
+		key.WithKeys("K", "shift+up"),
+		key.WithHelp("K", "previous lane"),
+	),
+	MoveLaneDown: key.NewBinding(
+		key.WithKeys("}"),
+		key.WithHelp("}", "move task a lane down"),
+	),
+	MoveLaneUp: key.NewBinding(
+		key.WithKeys("{"),
+		key.WithHelp("{", "move task a lane up"),
+	),
+	Collapse: key.NewBinding(
+		key.WithKeys("z"),
+		key.WithHelp("z", "collapse/expand lane"),
+	),
+	ToggleTags: key.NewBinding(
+		key.WithKeys("#"),
+		key.WithHelp("#", "toggle tags"),
+	),
+	AddTag: key.NewBinding(
+		key.WithKeys("+"),
+		key.WithHelp("+", "add tags"),
+	),
+	RemoveTag: key.NewBinding(
+		key.WithKeys("-"),
+		key.WithHelp("-", "remove a tag"),
+	),
+	TagSelect: key.NewBinding(
+		key.WithKeys(" "),
+		key.WithHelp("space", "toggle tag"),
+	),
+	Projects: key.NewBinding(
+		key.WithKeys("p"),
+		key.WithHelp("p", "project tree"),
+	),
+	RenameProject: key.NewBinding(
+		key.WithKeys("R"),
+		key.WithHelp("R", "rename project"),
+	),
+	NextSuggestion: key.NewBinding(
+		key.WithKeys("down", "ctrl+n"),
+		key.WithHelp("↓/ctrl+n", "next suggestion"),
+	),
+	PrevSuggestion: key.NewBinding(
+		key.WithKeys("up", "ctrl+p"),
+		key.WithHelp("↑/ctrl+p", "previous suggestion"),
+	),
 }
@@ -0,0 +1,270 @@
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/DerTimonius/twkb/styles"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// noLane is the lane of the tasks without a value for the grouping attribute.
+const noLane = "(none)"
+
+// minLaneHeight is the height of the columns of an expanded lane when not all
+// lanes fit on the screen.
+const minLaneHeight = 10
+
+// lane is a row of the board in swimlane mode, it has a column per status for
+// the tasks of one group.
+type lane struct {
+	name      string
+	cols      []column
+	collapsed bool
+}
+
+func (m *Board) newLane(name string, tasks []Task) lane {
+	cols := m.newColumns(tasks)
+	for i := range cols {
+		// the titles are shown once above the lanes, the counts in the lane header
+		cols[i].list.SetShowTitle(false)
+		cols[i].list.SetShowStatusBar(false)
+	}
+	return lane{name: name, cols: cols}
+}
+
+// newLanes groups the tasks by the attribute, there is a lane for every value.
+func (m *Board) newLanes(tasks []Task) []lane {
+	groups := map[string][]Task{}
+	var names []string
+	for _, t := range tasks {
+		name := laneOf(t, m.groupBy)
+		if _, ok := groups[name]; !ok {
+			names = append(names, name)
+		}
+		groups[name] = append(groups[name], t)
+	}
+	if len(names) == 0 {
+		names = append(names, noLane)
+	}
+	slices.SortFunc(names, compareLanes(m.groupBy))
+
+	lanes := make([]lane, len(names))
+	for i, name := range names {
+		lanes[i] = m.newLane(name, groups[name])
+	}
+	return lanes
+}
+
+// laneGroupings returns the attributes the lanes can be grouped by, a UDA set
+// in the config comes last.
+func laneGroupings() []string {
+	groupings := []string{"project", "tag", "priority"}
+	if config.Swimlanes != "" && !slices.Contains(groupings, config.Swimlanes) {
+		groupings = append(groupings, config.Swimlanes)
+	}
+	return groupings
+}
+
+// nextGrouping cycles from no lanes through all lane groupings.
+func nextGrouping(current string) string {
+	groupings := laneGroupings()
+	i := slices.Index(groupings, current)
+	if i == len(groupings)-1 {
+		return ""
+	}
+	return groupings[i+1]
+}
+
+// laneOf returns the lane of the task when grouped by the attribute. Tasks
+// with several tags are grouped by their first one.
+func laneOf(t Task, groupBy string) string {
+	var value string
+	switch groupBy {
+	case "project":
+		value = t.project
+	case "tag":
+		if len(t.tags) > 0 {
+			value = t.tags[0]
+		}
+	case "priority":
+		value = t.priority
+	default:
+		value = t.udas[groupBy]
+	}
+	if value == "" {
+		return noLane
+	}
+	return value
+}
+
+// compareLanes sorts the lanes by name and priorities from high to low. The
+// lane without a value always comes last.
+func compareLanes(groupBy string) func(a, b string) int {
+	priorities := []string{"H", "M", "L"}
+	return func(a, b string) int {
+		if a == noLane || b == noLane {
+			return cmp.Compare(boolInt(a == noLane), boolInt(b == noLane))
+		}
+		if groupBy == "priority" {
+			return cmp.Compare(slices.Index(priorities, a), slices.Index(priorities, b))
+		}
+		return cmp.Compare(a, b)
+	}
+}
+
+func boolInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// laneWindow returns the range of lanes that fit into the height, starting at
+// offset if possible but always containing the focused lane.
+func laneWindow(sizes []int, height, focused, offset int) (int, int) {
+	sum := func(lanes []int) int {
+		total := 0
+		for _, s := range lanes {
+			total += s
+		}
+		return total
+	}
+
+	offset = min(offset, focused)
+	for offset < focused && sum(sizes[offset:focused+1]) > height {
+		offset++
+	}
+	end := offset + 1
+	for end < len(sizes) && sum(sizes[offset:end+1]) <= height {
+		end++
+	}
+	// use the space above once scrolled to the last lanes
+	for offset > 0 && sum(sizes[offset-1:end]) <= height {
+		offset--
+	}
+	return offset, end
+}
+
+// laneIndex returns the index of the lane with the given name, the lane gets
+// added if it doesn't exist yet.
+func (m *Board) laneIndex(name string) (int, bool) {
+	i, found := slices.BinarySearchFunc(m.lanes, name, func(l lane, name string) int {
+		return compareLanes(m.groupBy)(l.name, name)
+	})
+	if found {
+		return i, false
+	}
+	m.lanes = slices.Insert(m.lanes, i, m.newLane(name, nil))
+	if i <= m.lane {
+		m.lane++
+	}
+	m.filterColumns()
+	return i, true
+}
+
+// focusLane moves the focus to the i-th lane, the focused column stays the same.
+func (m *Board) focusLane(i int) tea.Cmd {
+	if i < 0 || i >= len(m.lanes) {
+		return nil
+	}
+	m.cols[m.focused].Blur()
+	m.lane = i
+	m.cols = m.lanes[i].cols
+	m.cols[m.focused].Focus()
+	return m.resize()
+}
+
+// moveToLane moves the selected task to the lane above or below and changes
+// the grouping attribute accordingly.
+func (m *Board) moveToLane(delta int) tea.Cmd {
+	target := m.lane + delta
+	c := &m.cols[m.focused]
+	task, ok := c.list.SelectedItem().(Task)
+	if !ok || target < 0 || target >= len(m.lanes) || c.status == done || c.status == never {
+		return nil
+	}
+
+	task = task.MoveToLane(m.tw, m.groupBy, m.lanes[target].name)
+	c.list.RemoveItem(c.list.Index())
+	return m.set(task.status, APPEND, task)
+}
+
+// laneSizes returns the height of every lane, collapsed lanes only show their
+// header.
+func (m *Board) laneSizes(colHeight int) []int {
+	sizes := make([]int, len(m.lanes))
+	for i, l := range m.lanes {
+		sizes[i] = lipgloss.Height(m.laneHeader(i))
+		if !l.collapsed {
+			sizes[i] += colHeight
+		}
+	}
+	return sizes
+}
+
+// laneHeight shares the height between the expanded lanes, if there are too
+// many of them the lanes get scrolled instead.
+func (m *Board) laneHeight(height int) int {
+	var expanded int
+	for _, l := range m.lanes {
+		if !l.collapsed {
+			expanded++
+		}
+	}
+	if expanded == 0 {
+		return minLaneHeight
+	}
+	return max(minLaneHeight, (height-len(m.lanes))/expanded)
+}
+
+func (m *Board) laneHeader(i int) string {
+	l := m.lanes[i]
+	marker := "▾"
+	if l.collapsed {
+		marker = "▸"
+	}
+	counts := make([]string, len(l.cols))
+	for j, c := range l.cols {
+		counts[j] = fmt.Sprintf("%s %d", c.status.title(), len(c.tasks()))
+	}
+	style := styles.LaneHeaderStyle
+	if i == m.lane {
+		style = styles.FocusedLaneHeaderStyle
+	}
+	return style.Render(fmt.Sprintf("%s %s  %s", marker, l.name, strings.Join(counts, " • ")))
+}
+
+// columnTitles shows the titles of the visible columns once above all lanes.
+func (m *Board) columnTitles() string {
+	_, n := columnWindow(m.boardWidth(), len(m.cols), m.focused, m.offset)
+	titles := make([]string, n)
+	for i := range titles {
+		title, style := m.columnTitle(m.cols[m.offset+i].status)
+		titles[i] = styles.ColumnTitleStyle.Copy().Width(m.boardWidth() / n).Render(style.Render(title))
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Top, titles...)
+}
+
+// lanesView renders the visible lanes below each other.
+func (m *Board) lanesView(height int) string {
+	_, n := columnWindow(m.boardWidth(), len(m.cols), m.focused, m.offset)
+	start, end := laneWindow(m.laneSizes(m.laneHeight(height)), height, m.lane, m.laneOffset)
+
+	rows := []string{m.columnTitles()}
+	for i := start; i < end; i++ {
+		rows = append(rows, m.laneHeader(i))
+		if m.lanes[i].collapsed {
+			continue
+		}
+		views := make([]string, n)
+		for j := range views {
+			views[j] = m.columnView(i, m.lanes[i].cols[m.offset+j])
+		}
+		rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Left, views...))
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, rows...)
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestLaneOf(t *testing.T) {
+	task := Task{
+		project:  "home",
+		priority: "M",
+		tags:     []string{"errand", "car"},
+		udas:     map[string]string{"estimate": "large"},
+	}
+	tests := []struct {
+		groupBy  string
+		task     Task
+		expected string
+	}{
+		{"project", task, "home"},
+		{"tag", task, "errand"},
+		{"priority", task, "M"},
+		{"estimate", task, "large"},
+		{"project", Task{}, noLane},
+		{"tag", Task{}, noLane},
+		{"estimate", Task{}, noLane},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.groupBy, func(t *testing.T) {
+			if result := laneOf(tt.task, tt.groupBy); result != tt.expected {
+				t.Errorf("laneOf(%v, %q) = %q, want %q", tt.task, tt.groupBy, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestNextGrouping(t *testing.T) {
+	config = defaultConfig()
+	config.Swimlanes = "estimate"
+	t.Cleanup(func() { config = defaultConfig() })
+
+	var groupings []string
+	groupBy := ""
+	for range 5 {
+		groupBy = nextGrouping(groupBy)
+		groupings = append(groupings, groupBy)
+	}
+	expected := []string{"project", "tag", "priority", "estimate", ""}
+	if !slices.Equal(groupings, expected) {
+		t.Errorf("nextGrouping cycles through %q, want %q", groupings, expected)
+	}
+}
+
+func TestCompareLanes(t *testing.T) {
+	tests := []struct {
+		name     string
+		groupBy  string
+		lanes    []string
+		expected []string
+	}{
+		{"Projects by name", "project", []string{"work", noLane, "home"}, []string{"home", "work", noLane}},
+		{"Priorities from high to low", "priority", []string{"L", noLane, "H", "M"}, []string{"H", "M", "L", noLane}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			slices.SortFunc(tt.lanes, compareLanes(tt.groupBy))
+			if !slices.Equal(tt.lanes, tt.expected) {
+				t.Errorf("sorted lanes = %q, want %q", tt.lanes, tt.expected)
+			}
+		})
+	}
+}
+
+func TestLaneWindow(t *testing.T) {
+	tests := []struct {
+		name          string
+		sizes         []int
+		height        int
+		focused       int
+		offset        int
+		expectedStart int
+		expectedEnd   int
+	}{
+		{"All lanes fit", []int{10, 1, 10}, 30, 2, 0, 0, 3},
+		{"Window follows the focus down", []int{10, 10, 10, 10}, 25, 3, 0, 2, 4},
+		{"Window follows the focus up", []int{10, 10, 10, 10}, 25, 0, 2, 0, 2},
+		{"Window stays while the focus is visible", []int{10, 10, 10, 10}, 25, 2, 1, 1, 3},
+		{"Focused lane is shown even if it is too high", []int{10, 40, 10}, 25, 1, 0, 1, 2},
+		{"Space above is used at the end", []int{10, 10, 10, 1, 1}, 25, 4, 4, 1, 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end := laneWindow(tt.sizes, tt.height, tt.focused, tt.offset)
+			if start != tt.expectedStart || end != tt.expectedEnd {
+				t.Errorf("laneWindow() = %d, %d, want %d, %d", start, end, tt.expectedStart, tt.expectedEnd)
+			}
+		})
+	}
+}
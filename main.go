@@ -1,31 +1,56 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"os"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 )
 
 type status int
 
-func (s status) getNext() status {
-	if s == done {
-		return todo
+func (s status) String() string {
+	switch s {
+	case todo:
+		return "todo"
+	case inProgress:
+		return "doing"
+	case done:
+		return "done"
 	}
-	return s + 1
+	return "deleted"
 }
 
-func (s status) getPrev() status {
-	if s == todo {
-		return done
+func (s status) title() string {
+	switch s {
+	case todo:
+		return "To Do"
+	case inProgress:
+		return "In Progress"
+	case done:
+		return "Done"
 	}
-	return s - 1
+	return "Deleted"
+}
+
+// parseStatus is the inverse of status.String, used for the column names in
+// the config.
+func parseStatus(name string) (status, error) {
+	for _, s := range []status{todo, inProgress, done, never} {
+		if s.String() == name {
+			return s, nil
+		}
+	}
+	return todo, fmt.Errorf("unknown column %q, use todo, doing, done or deleted", name)
 }
 
 const margin = 3
 
-var board *Board
+// minColumnWidth is the width below which the board shows fewer columns at
+// once and pages through them instead.
+const minColumnWidth = 36
 
 const (
 	todo status = iota
@@ -35,6 +60,9 @@ const (
 )
 
 func main() {
+	view := flag.String("view", "", "start with the named `view` of the config")
+	flag.Parse()
+
 	f, err := tea.LogToFile("/tmp/debug.log", "debug")
 	if err != nil {
 		fmt.Println(err)
@@ -42,9 +70,64 @@ func main() {
 	}
 	defer f.Close()
 
-	board = NewBoard()
-	board.initLists()
-	p := tea.NewProgram(board)
+	config, err = loadConfig()
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	tw := execBackend{}
+	switch flag.Arg(0) {
+	case "export":
+		if err := runExport(tw, flag.Args()[1:], *view); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		return
+	case "import":
+		if err := runImport(tw, flag.Args()[1:]); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		return
+	case "list":
+		if err := runList(tw, flag.Args()[1:], *view); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		return
+	case "move":
+		if err := runMove(tw, flag.Args()[1:], *view); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		return
+	case "summary":
+		if err := runSummary(tw, flag.Args()[1:], *view); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		return
+	case "snapshot":
+		if err := runSnapshot(tw, flag.Args()[1:], *view); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	b, err := loadBoard(tw, *view)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	p := tea.NewProgram(NewApp(b), tea.WithMouseCellMotion())
+	// running timers need a refresh every second, due dates every minute
+	if config.Timewarrior {
+		go tick(p, time.Second)
+	} else {
+		go tick(p, time.Minute)
+	}
 	if _, err := p.Run(); err != nil {
 		fmt.Println(err)
 		os.Exit(1)
@@ -0,0 +1,223 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/DerTimonius/twkb/styles"
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// startedAt returns when the work on the task started. Taskwarrior removes
+// the start of a task once it is done, the first interval tracked with
+// timewarrior still knows it.
+func (t Task) startedAt() time.Time {
+	if !t.start.IsZero() {
+		return t.start
+	}
+	return t.firstTracked
+}
+
+// columnSince returns when the task entered its current column.
+func (t Task) columnSince() time.Time {
+	switch t.status {
+	case inProgress:
+		return t.start
+	case done, never:
+		return t.end
+	}
+	return t.entry
+}
+
+// age returns for how long the task is in its column, 0 if that is unknown.
+func (t Task) age(now time.Time) time.Duration {
+	since := t.columnSince()
+	if since.IsZero() || since.After(now) {
+		return 0
+	}
+	return now.Sub(since)
+}
+
+// idle reports whether the task is in its column for longer than the aging
+// limit of the column.
+func (t Task) idle(now time.Time) bool {
+	days := config.Aging[t.status.String()]
+	return days > 0 && t.age(now) >= time.Duration(days)*24*time.Hour
+}
+
+// leadTime is the time from the creation to the completion of the task.
+func (t Task) leadTime() (time.Duration, bool) {
+	if t.status != done || t.entry.IsZero() || t.end.IsZero() {
+		return 0, false
+	}
+	return t.end.Sub(t.entry), true
+}
+
+// cycleTime is the time from the start to the completion of the task.
+func (t Task) cycleTime() (time.Duration, bool) {
+	start := t.startedAt()
+	if t.status != done || start.IsZero() || t.end.IsZero() {
+		return 0, false
+	}
+	return t.end.Sub(start), true
+}
+
+// percentile returns the p-th percentile of the durations with the nearest
+// rank method, p is between 0 and 1.
+func percentile(durations []time.Duration, p float64) time.Duration {
+	if len(durations) == 0 {
+		return 0
+	}
+	sorted := slices.Clone(durations)
+	slices.Sort(sorted)
+	rank := int(math.Ceil(p * float64(len(sorted))))
+	return sorted[max(0, rank-1)]
+}
+
+// metricRange is a range of days the flow report covers, 0 means all tasks.
+type metricRange int
+
+var metricRanges = []metricRange{7, 30, 90, 0}
+
+func (r metricRange) String() string {
+	if r == 0 {
+		return "all time"
+	}
+	return fmt.Sprintf("last %d days", int(r))
+}
+
+// durationBucket groups the durations for the distribution.
+type durationBucket struct {
+	label string
+	upTo  time.Duration
+}
+
+var durationBuckets = []durationBucket{
+	{"< 1d", 24 * time.Hour},
+	{"1-3d", 3 * 24 * time.Hour},
+	{"3-7d", 7 * 24 * time.Hour},
+	{"1-2w", 14 * 24 * time.Hour},
+	{"2-4w", 28 * 24 * time.Hour},
+	{"> 4w", math.MaxInt64},
+}
+
+// distribution counts the durations per bucket.
+func distribution(durations []time.Duration) []int {
+	counts := make([]int, len(durationBuckets))
+	for _, d := range durations {
+		for i, b := range durationBuckets {
+			if d < b.upTo {
+				counts[i]++
+				break
+			}
+		}
+	}
+	return counts
+}
+
+// flowTimes returns the lead and cycle times of the tasks completed in the
+// range before now.
+func flowTimes(tasks []Task, r metricRange, now time.Time) (lead, cycle []time.Duration) {
+	from := startOfDay(now).AddDate(0, 0, -int(r)+1)
+	for _, t := range tasks {
+		if r != 0 && t.end.Before(from) {
+			continue
+		}
+		if d, ok := t.leadTime(); ok {
+			lead = append(lead, d)
+		}
+		if d, ok := t.cycleTime(); ok {
+			cycle = append(cycle, d)
+		}
+	}
+	return lead, cycle
+}
+
+// FlowReport shows the distribution of the lead and cycle times of the done
+// tasks. The range of the report is switched with left and right.
+type FlowReport struct {
+	help  help.Model
+	tasks []Task
+	r     int
+	now   time.Time
+}
+
+func NewFlowReport(tasks []Task, now time.Time) *FlowReport {
+	return &FlowReport{help: help.New(), tasks: tasks, r: 1, now: now}
+}
+
+func (f FlowReport) Init() tea.Cmd {
+	return nil
+}
+
+func (f FlowReport) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, keys.Left):
+			f.r = max(0, f.r-1)
+		case key.Matches(msg, keys.Right):
+			f.r = min(len(metricRanges)-1, f.r+1)
+		case key.Matches(msg, keys.Back), key.Matches(msg, keys.FlowReport):
+			return f, closeView
+		case key.Matches(msg, keys.Quit):
+			return f, tea.Quit
+		}
+	}
+	return f, nil
+}
+
+func (f FlowReport) View() string {
+	r := metricRanges[f.r]
+	lead, cycle := flowTimes(f.tasks, r, f.now)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%-22s %10s %10s\n", "Completed tasks", "Lead", "Cycle")
+	b.WriteString(strings.Repeat("─", 44))
+	fmt.Fprintf(&b, "\n%-22s %10d %10d", "tasks", len(lead), len(cycle))
+	fmt.Fprintf(&b, "\n%-22s %10s %10s", "median", formatMetric(lead, 0.5), formatMetric(cycle, 0.5))
+	fmt.Fprintf(&b, "\n%-22s %10s %10s", "85th percentile", formatMetric(lead, 0.85), formatMetric(cycle, 0.85))
+
+	leadCounts, cycleCounts := distribution(lead), distribution(cycle)
+	highest := max(1, slices.Max(leadCounts), slices.Max(cycleCounts))
+	fmt.Fprintf(&b, "\n\n%-6s %-22s %-22s", "", "Lead", "Cycle")
+	for i, bucket := range durationBuckets {
+		fmt.Fprintf(&b, "\n%-6s %-22s %-22s", bucket.label, distributionBar(leadCounts[i], highest), distributionBar(cycleCounts[i], highest))
+	}
+
+	legend := "lead: entry → end, cycle: start → end"
+	if !config.Timewarrior {
+		legend += "\ncycle times need timewarrior, taskwarrior forgets the start of done tasks"
+	}
+
+	return styles.FormStyle.Render(
+		lipgloss.JoinVertical(
+			lipgloss.Left,
+			styles.TitleStyle.Render(fmt.Sprintf("Lead and cycle time • ‹ %s ›", r)),
+			b.String(),
+			"",
+			styles.PreviewStyle.Render(legend),
+			f.help.ShortHelpView(keys.FlowReportHelp()),
+		),
+	)
+}
+
+func formatMetric(durations []time.Duration, p float64) string {
+	if len(durations) == 0 {
+		return "n/a"
+	}
+	return humanizeDuration(percentile(durations, p))
+}
+
+func distributionBar(count, highest int) string {
+	if count == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%s %d", strings.Repeat("█", max(1, count*16/highest)), count)
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestPercentile(t *testing.T) {
+	day := 24 * time.Hour
+	durations := []time.Duration{5 * day, day, 3 * day, 2 * day, 4 * day, 10 * day}
+
+	tests := []struct {
+		p        float64
+		expected time.Duration
+	}{
+		{0.5, 3 * day},
+		{0.85, 10 * day},
+		{0.8, 5 * day},
+		{0, day},
+		{1, 10 * day},
+	}
+	for _, tt := range tests {
+		if got := percentile(durations, tt.p); got != tt.expected {
+			t.Errorf("percentile(%v) = %v, want %v", tt.p, got, tt.expected)
+		}
+	}
+	if got := percentile(nil, 0.5); got != 0 {
+		t.Errorf("percentile of no durations = %v, want 0", got)
+	}
+	if durations[0] != 5*day {
+		t.Error("percentile must not sort the durations in place")
+	}
+}
+
+func TestAge(t *testing.T) {
+	config = defaultConfig()
+	now := time.Date(2024, 3, 15, 12, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		name     string
+		task     Task
+		expected time.Duration
+		idle     bool
+	}{
+		{"Todo since its entry", Task{status: todo, entry: now.Add(-31 * 24 * time.Hour)}, 31 * 24 * time.Hour, true},
+		{"Doing since its start", Task{status: inProgress, entry: now.Add(-40 * 24 * time.Hour), start: now.Add(-2 * time.Hour)}, 2 * time.Hour, false},
+		{"Idle in doing", Task{status: inProgress, start: now.Add(-8 * 24 * time.Hour)}, 8 * 24 * time.Hour, true},
+		{"Done since its end", Task{status: done, entry: now.Add(-90 * 24 * time.Hour), end: now.Add(-time.Hour)}, time.Hour, false},
+		{"Unknown entry", Task{status: todo}, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.task.age(now); got != tt.expected {
+				t.Errorf("age() = %v, want %v", got, tt.expected)
+			}
+			if got := tt.task.idle(now); got != tt.idle {
+				t.Errorf("idle() = %v, want %v", got, tt.idle)
+			}
+		})
+	}
+}
+
+func TestFlowTimes(t *testing.T) {
+	now := time.Date(2024, 3, 15, 12, 0, 0, 0, time.Local)
+	day := 24 * time.Hour
+	tasks := []Task{
+		// taskwarrior removed the starts, timewarrior knows them
+		{status: done, entry: now.Add(-5 * day), firstTracked: now.Add(-2 * day), end: now.Add(-day)},
+		{status: done, entry: now.Add(-20 * day), firstTracked: now.Add(-12 * day), end: now.Add(-10 * day)},
+		{status: done, entry: now.Add(-3 * day), end: now.Add(-2 * day)},
+		{status: inProgress, entry: now.Add(-3 * day), start: now.Add(-day)},
+		{status: never, entry: now.Add(-3 * day), end: now.Add(-day)},
+	}
+
+	lead, cycle := flowTimes(tasks, 7, now)
+	if !slices.Equal(lead, []time.Duration{4 * day, day}) || !slices.Equal(cycle, []time.Duration{day}) {
+		t.Errorf("flowTimes(7 days) = %v, %v", lead, cycle)
+	}
+
+	lead, cycle = flowTimes(tasks, 0, now)
+	if !slices.Equal(lead, []time.Duration{4 * day, 10 * day, day}) || !slices.Equal(cycle, []time.Duration{day, 2 * day}) {
+		t.Errorf("flowTimes(all) = %v, %v", lead, cycle)
+	}
+
+	if got := distribution(lead); !slices.Equal(got, []int{0, 1, 1, 1, 0, 0}) {
+		t.Errorf("distribution(%v) = %v", lead, got)
+	}
+}
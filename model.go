@@ -1,24 +1,89 @@
 package main
 
 import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/DerTimonius/twkb/styles"
 	"github.com/charmbracelet/bubbles/help"
 	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
 
+type tickMsg time.Time
+
+// tick refreshes the board in the given interval, e.g. to advance running
+// timers and relative due dates. The ticks are sent from outside of the
+// models, so they keep coming while a form is open.
+func tick(p *tea.Program, interval time.Duration) {
+	for t := range time.Tick(interval) {
+		p.Send(tickMsg(t))
+	}
+}
+
+// noticeMsg is shown in the header until the next key press.
+type noticeMsg string
+
+func notify(notice string) tea.Cmd {
+	return func() tea.Msg { return noticeMsg(notice) }
+}
+
 type Board struct {
+	// tw runs the commands of the board
+	tw       Backend
 	help     help.Model
+	context  string
+	statuses []status
 	cols     []column
-	focused  status
-	loaded   bool
-	quitting bool
+	focused  int
+	// offset is the first visible column when not all columns fit
+	offset int
+	// lanes are only set in swimlane mode, cols are the columns of the
+	// focused lane then
+	lanes      []lane
+	lane       int
+	laneOffset int
+	groupBy    string
+	// view is the name of the active view, its filter is applied on top of
+	// the context. filters are all filters the tasks were loaded with.
+	view    string
+	filter  string
+	filters []string
+	sortBy  string
+	compact bool
+	search  search
+	// project shows only the tasks of the project and its subprojects
+	project string
+	sidebar sidebar
+	notice  string
+	// zones are where the columns and cards were drawn the last time
+	zones map[zoneID]zone
+	// clicked is the card clicked last, to notice double clicks, dragged the
+	// card while the button is held
+	clicked   zoneID
+	clickedAt time.Time
+	dragged   *zoneID
+	width     int
+	height    int
+	loaded    bool
+	quitting  bool
 }
 
-func NewBoard() *Board {
+func NewBoard(tw Backend) *Board {
 	help := help.New()
 	help.ShowAll = true
-	return &Board{help: help, focused: todo}
+	return &Board{
+		tw:       tw,
+		help:     help,
+		search:   newSearch(),
+		statuses: columnStatuses(config.Columns),
+		sortBy:   sortOrders[0],
+		groupBy:  config.Swimlanes,
+		compact:  config.Card.Compact,
+	}
 }
 
 func (m *Board) Init() tea.Cmd {
@@ -28,86 +93,475 @@ func (m *Board) Init() tea.Cmd {
 func (m *Board) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
-		var cmd tea.Cmd
-		var cmds []tea.Cmd
-		m.help.Width = msg.Width - margin
-		for i := 0; i < len(m.cols); i++ {
-			var res tea.Model
-			res, cmd = m.cols[i].Update(msg)
-			m.cols[i] = res.(column)
-			cmds = append(cmds, cmd)
-		}
-		m.loaded = true
-		return m, tea.Batch(cmds...)
-	case TaskForm:
-		if msg.isEdit {
-			return m, m.cols[m.focused].Set(
-				msg.index,
-				msg.relatedTask.ModifyTask(&msg),
-			)
-		}
-		return m, m.cols[todo].Set(msg.index, msg.CreateTask())
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, m.resize()
+	case taskSavedMsg:
+		if msg.edit {
+			return m, m.set(m.cols[m.focused].status, msg.index, modifyTask(m.tw, msg.cmd, msg.task))
+		}
+		return m, m.set(todo, msg.index, addTask(m.tw, msg.cmd, msg.task))
+	case searchResult:
+		// the query changed in the meantime
+		if msg.query != m.search.query() {
+			return m, nil
+		}
+		m.search.err = msg.err
+		if msg.err == nil {
+			m.search.uuids = msg.uuids
+			m.filterColumns()
+		}
+		return m, nil
+	case tea.MouseMsg:
+		return m, m.updateMouse(msg)
+	case noticeMsg:
+		m.notice = string(msg)
+		return m, nil
+	case tickMsg:
+		// re-rendering is enough to advance the timers and due dates
+		return m, nil
 	case moveMsg:
-		return m, m.cols[msg.Task.status].Set(APPEND, msg.Task)
-	case Confirmation:
-		return m, msg.confirm()
-	case Block:
-		tasks := msg.GetSelectedTasks()
-		blocker := msg.blocking
-		msg.blocking.BlockTasks(&tasks)
-		todoItems := m.cols[todo].list.Items()
-		for i, item := range todoItems {
-			task := item.(Task)
-			for _, blockedTask := range tasks {
-				if task.uuid == blockedTask.uuid && blockedTask.blocked {
-					task.blocked = true
-					task.UpdateUrgency()
-					m.cols[todo].list.SetItem(i, task)
-					break
-				}
-				if task.uuid == blocker.uuid {
-					task.UpdateUrgency()
-					m.cols[task.status].list.SetItem(i, task)
+		return m, m.set(msg.Task.status, APPEND, msg.Task)
+	case projectRenamedMsg:
+		for _, cmdStr := range msg.cmds {
+			if _, err := m.tw.Run(cmdStr); err != nil {
+				// the board shows what got renamed before the error
+				return m, tea.Batch(notify(fmt.Sprintf("Could not rename %s: %v", msg.from, err)), m.reload())
+			}
+		}
+		if inProject(m.project, msg.from) {
+			m.project = msg.to + strings.TrimPrefix(m.project, msg.from)
+		}
+		return m, m.reload()
+	case exportMsg:
+		return m, m.export(msg.path)
+	case tagsMsg:
+		return m, m.set(m.cols[m.focused].status, msg.index, msg.task.SetTags(m.tw, msg.tags))
+	case viewSelectedMsg:
+		if err := m.setView(msg.name); err != nil {
+			return m, notify(err.Error())
+		}
+		return m, m.reload()
+	case contextSelectedMsg:
+		cmdStr, err := SetContextCmd(msg.name)
+		if err != nil {
+			return m, notify(err.Error())
+		}
+		if _, err := m.tw.Run(cmdStr); err != nil {
+			return m, notify(fmt.Sprintf("Could not switch to the context %s: %v", msg.name, err))
+		}
+		return m, m.reload()
+	case confirmedMoveMsg:
+		return m, m.move(msg.target)
+	case deleteMsg:
+		return m, m.focusedColumn().DeleteCurrent()
+	case unblockMsg:
+		return m, m.focusedColumn().Unblock()
+	case blockMsg:
+		tasks := msg.blocked
+		blocker := msg.blocker
+		msg.blocker.BlockTasks(m.tw, &tasks)
+		for _, todoCol := range m.allColumns() {
+			if todoCol.status != todo {
+				continue
+			}
+			for i, item := range todoCol.list.Items() {
+				task := item.(Task)
+				for _, blockedTask := range tasks {
+					if task.uuid == blockedTask.uuid && blockedTask.blocked {
+						task.blocked = true
+						task.UpdateUrgency(m.tw)
+						todoCol.list.SetItem(i, task)
+						break
+					}
+					if task.uuid == blocker.uuid {
+						task.UpdateUrgency(m.tw)
+						todoCol.list.SetItem(i, task)
+					}
 				}
 			}
 		}
 	case tea.KeyMsg:
+		m.notice = ""
+		if m.search.input.Focused() {
+			return m, m.updateSearch(msg)
+		}
+		if m.sidebar.focused {
+			return m.updateSidebar(msg)
+		}
 		switch {
+		case key.Matches(msg, keys.Filter):
+			m.search.input.Focus()
+			return m, tea.Batch(textinput.Blink, m.resize())
+		case key.Matches(msg, keys.Back) && m.search.shown():
+			return m, m.clearSearch()
+		case key.Matches(msg, keys.Back) && m.project != "":
+			m.project = ""
+			m.filterColumns()
+			return m, nil
+		case key.Matches(msg, keys.Projects):
+			m.sidebar.shown, m.sidebar.focused = true, true
+			return m, m.resize()
 		case key.Matches(msg, keys.Quit):
 			m.quitting = true
 			return m, tea.Quit
 		case key.Matches(msg, keys.Left):
-			m.cols[m.focused].Blur()
-			m.focused = m.focused.getPrev()
-			m.cols[m.focused].Focus()
+			return m, m.focus((m.focused - 1 + len(m.cols)) % len(m.cols))
 		case key.Matches(msg, keys.Right):
+			return m, m.focus((m.focused + 1) % len(m.cols))
+		case key.Matches(msg, keys.Compact):
+			m.compact = !m.compact
+			m.filterColumns()
+			return m, nil
+		case key.Matches(msg, keys.Swimlanes):
+			tasks := m.tasks()
 			m.cols[m.focused].Blur()
-			m.focused = m.focused.getNext()
-			m.cols[m.focused].Focus()
+			m.groupBy = nextGrouping(m.groupBy)
+			m.build(tasks)
+			return m, m.resize()
+		case m.lanes != nil && key.Matches(msg, keys.NextLane):
+			return m, m.focusLane(m.lane + 1)
+		case m.lanes != nil && key.Matches(msg, keys.PrevLane):
+			return m, m.focusLane(m.lane - 1)
+		case m.lanes != nil && key.Matches(msg, keys.MoveLaneDown):
+			return m, m.moveToLane(1)
+		case m.lanes != nil && key.Matches(msg, keys.MoveLaneUp):
+			return m, m.moveToLane(-1)
+		case m.lanes != nil && key.Matches(msg, keys.Collapse):
+			m.lanes[m.lane].collapsed = !m.lanes[m.lane].collapsed
+			return m, m.resize()
+		case key.Matches(msg, keys.QuickAdd):
+			q := NewQuickAdd()
+			return m, openView(q)
+		case key.Matches(msg, keys.Context):
+			contexts, err := getContexts(m.tw)
+			if err != nil {
+				return m, notify(fmt.Sprintf("Could not read the contexts: %v", err))
+			}
+			p := NewContextPicker(contexts, m.context, 61, m.height/2)
+			return m, openView(p)
+		case key.Matches(msg, keys.View):
+			p := NewViewPicker(config.Views, m.view, 61, m.height/2)
+			return m, openView(p)
+		case key.Matches(msg, keys.Stats):
+			d := NewDashboard(m.filteredTasks(), m.statuses, time.Now())
+			return m, openView(d)
+		case key.Matches(msg, keys.FlowReport):
+			f := NewFlowReport(m.filteredTasks(), time.Now())
+			return m, openView(f)
+		case key.Matches(msg, keys.Export):
+			e := NewExportForm()
+			return m, openView(e)
+		case key.Matches(msg, keys.TimeSummary):
+			if config.Timewarrior {
+				s := NewTimeSummary(m.allColumns())
+				return m, openView(s)
+			}
+		}
+	}
+	// the cards of a collapsed lane are hidden, so are its columns
+	if _, ok := msg.(tea.KeyMsg); ok && m.lanes != nil && m.lanes[m.lane].collapsed {
+		return m, nil
+	}
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		if cmd, ok := m.updateCard(msg); ok {
+			return m, cmd
 		}
 	}
 	res, cmd := m.cols[m.focused].Update(msg)
-	if _, ok := res.(column); ok {
-		m.cols[m.focused] = res.(column)
+	m.cols[m.focused] = res.(column)
+	return m, cmd
+}
+
+// build puts the tasks into the columns, or into lanes of columns when the
+// board is grouped.
+func (m *Board) build(tasks []Task) {
+	m.lanes = nil
+	if m.groupBy == "" {
+		m.cols = m.newColumns(tasks)
 	} else {
-		return res, cmd
+		m.lanes = m.newLanes(tasks)
+		m.lane, m.laneOffset = 0, 0
+		m.cols = m.lanes[0].cols
 	}
-	return m, cmd
+	m.cols[m.focused].Focus()
+	m.filterColumns()
+}
+
+// allColumns returns the columns of all lanes.
+func (m *Board) allColumns() []*column {
+	var cols []*column
+	if m.lanes == nil {
+		for i := range m.cols {
+			cols = append(cols, &m.cols[i])
+		}
+		return cols
+	}
+	for i := range m.lanes {
+		for j := range m.lanes[i].cols {
+			cols = append(cols, &m.lanes[i].cols[j])
+		}
+	}
+	return cols
+}
+
+// tasks returns the tasks of all columns.
+func (m *Board) tasks() []Task {
+	var tasks []Task
+	for _, c := range m.allColumns() {
+		tasks = append(tasks, c.tasks()...)
+	}
+	return tasks
+}
+
+// column returns the column showing the tasks with the given status, or nil if
+// there is no such column on the board.
+func (m *Board) column(s status) *column {
+	for i := range m.cols {
+		if m.cols[i].status == s {
+			return &m.cols[i]
+		}
+	}
+	return nil
+}
+
+// focusedColumn returns the column that has the focus.
+func (m *Board) focusedColumn() *column {
+	return &m.cols[m.focused]
+}
+
+// set puts the task into the column of the given status. Tasks of a status
+// without a column are left out. In swimlane mode the task goes into the lane
+// of its group, i.e. an edit can move it to another lane.
+func (m *Board) set(s status, i int, t Task) tea.Cmd {
+	if m.lanes == nil {
+		c := m.column(s)
+		if c == nil {
+			return nil
+		}
+		return c.Set(i, t)
+	}
+
+	var cmds []tea.Cmd
+	l, added := m.laneIndex(laneOf(t, m.groupBy))
+	m.cols = m.lanes[m.lane].cols
+	if i != APPEND && l != m.lane {
+		if c := m.column(s); c != nil {
+			c.list.RemoveItem(i)
+		}
+		i = APPEND
+	}
+	if added {
+		cmds = append(cmds, m.resize())
+	}
+	for j := range m.lanes[l].cols {
+		if c := &m.lanes[l].cols[j]; c.status == s {
+			cmds = append(cmds, c.Set(i, t))
+		}
+	}
+	return tea.Batch(cmds...)
+}
+
+// focus moves the focus to the i-th column and scrolls it into view.
+func (m *Board) focus(i int) tea.Cmd {
+	m.cols[m.focused].Blur()
+	m.focused = i
+	m.cols[m.focused].Focus()
+	return m.resize()
+}
+
+// columnWindow returns the first and the number of the columns that fit next
+// to each other into the width. The window starts at offset if possible, but
+// always contains the focused column.
+func columnWindow(width, count, focused, offset int) (int, int) {
+	n := max(1, min(count, width/minColumnWidth))
+	if focused < offset {
+		offset = focused
+	}
+	if focused >= offset+n {
+		offset = focused - n + 1
+	}
+	return max(0, min(offset, count-n)), n
+}
+
+// resize shares the window between the visible columns. Their height is what
+// is left by the header, the help and the column indicator. In swimlane mode
+// the lanes share that height.
+func (m *Board) resize() tea.Cmd {
+	var n int
+	m.offset, n = columnWindow(m.boardWidth(), len(m.cols), m.focused, m.offset)
+	m.help.Width = m.width - margin
+	height := m.boardHeight()
+	m.loaded = true
+	if m.lanes == nil {
+		return resizeColumns(m.cols, m.boardWidth()/n, height)
+	}
+
+	var cmds []tea.Cmd
+	laneHeight := m.laneHeight(height)
+	m.laneOffset, _ = laneWindow(m.laneSizes(laneHeight), height, m.lane, m.laneOffset)
+	for i := range m.lanes {
+		cmds = append(cmds, resizeColumns(m.lanes[i].cols, m.boardWidth()/n, laneHeight))
+	}
+	return tea.Batch(cmds...)
+}
+
+func resizeColumns(cols []column, width, height int) tea.Cmd {
+	var cmds []tea.Cmd
+	msg := tea.WindowSizeMsg{Width: width, Height: height}
+	for i := 0; i < len(cols); i++ {
+		res, cmd := cols[i].Update(msg)
+		cols[i] = res.(column)
+		cmds = append(cmds, cmd)
+	}
+	return tea.Batch(cmds...)
+}
+
+// boardHeight is the height left for the columns or lanes.
+func (m *Board) boardHeight() int {
+	_, n := columnWindow(m.boardWidth(), len(m.cols), m.focused, m.offset)
+	height := m.height - lipgloss.Height(m.header()) - lipgloss.Height(m.helpView())
+	if n < len(m.cols) {
+		height -= lipgloss.Height(m.indicator())
+	}
+	if m.lanes != nil {
+		height -= lipgloss.Height(m.columnTitles())
+	}
+	if m.search.shown() {
+		height -= lipgloss.Height(m.search.View())
+	}
+	return height
+}
+
+// reload reads all tasks from taskwarrior again, e.g. after switching context.
+func (m *Board) reload() tea.Cmd {
+	m.initLists()
+	m.offset = 0
+	return m.resize()
+}
+
+func (m *Board) header() string {
+	context := m.context
+	if context == "" {
+		context = noContext
+	}
+	header := "twkb"
+	if m.view != "" {
+		header += fmt.Sprintf(" • view: %s", m.view)
+	}
+	header += fmt.Sprintf(" • context: %s", context)
+	if m.groupBy != "" {
+		header += fmt.Sprintf(" • lanes: %s", m.groupBy)
+	}
+	if m.project != "" {
+		header += fmt.Sprintf(" • project: %s", m.project)
+	}
+	if m.notice != "" {
+		header += " • " + styles.ErrorStyle.Render(m.notice)
+	}
+	return styles.HeaderStyle.Render(header)
+}
+
+// indicator lists all columns when only some of them fit on the screen, the
+// arrows show on which side more columns are hidden.
+func (m *Board) indicator() string {
+	_, n := columnWindow(m.boardWidth(), len(m.cols), m.focused, m.offset)
+	titles := make([]string, len(m.cols))
+	for i, c := range m.cols {
+		switch {
+		case i == m.focused:
+			titles[i] = styles.ActiveIndicatorStyle.Render(c.list.Title)
+		case i >= m.offset && i < m.offset+n:
+			titles[i] = styles.VisibleIndicatorStyle.Render(c.list.Title)
+		default:
+			titles[i] = styles.IndicatorStyle.Render(c.list.Title)
+		}
+	}
+	left, right := " ", " "
+	if m.offset > 0 {
+		left = "‹"
+	}
+	if m.offset+n < len(m.cols) {
+		right = "›"
+	}
+	return styles.HeaderStyle.Render(fmt.Sprintf("%s %s %s", left, strings.Join(titles, " • "), right))
 }
 
 // Changing to pointer receiver to get back to this model after adding a new task via the form... Otherwise I would need to pass this model along to the form and it becomes highly coupled to the other models.
 func (m *Board) View() string {
+	var view string
+	view, m.zones = scanZones(m.render())
+	return view
+}
+
+// render renders the board with the columns and cards marked.
+func (m *Board) render() string {
 	if m.quitting {
 		return ""
 	}
 	if !m.loaded {
 		return "loading..."
 	}
-	board := lipgloss.JoinHorizontal(
-		lipgloss.Left,
-		m.cols[todo].View(),
-		m.cols[inProgress].View(),
-		m.cols[done].View(),
-	)
-	return lipgloss.JoinVertical(lipgloss.Left, board, m.help.View(keys))
+	// the titles show the number of tasks of columns with a WIP limit
+	for _, c := range m.allColumns() {
+		c.list.Title, c.list.Styles.Title = m.columnTitle(c.status)
+	}
+
+	_, n := columnWindow(m.boardWidth(), len(m.cols), m.focused, m.offset)
+	var board string
+	if m.lanes != nil {
+		board = m.lanesView(m.boardHeight())
+	} else {
+		views := make([]string, n)
+		for i := range views {
+			views[i] = m.columnView(0, m.cols[m.offset+i])
+		}
+		board = lipgloss.JoinHorizontal(lipgloss.Left, views...)
+	}
+
+	if m.sidebar.shown {
+		board = lipgloss.JoinHorizontal(lipgloss.Top, m.sidebarView(lipgloss.Height(board)), board)
+	}
+
+	rows := []string{m.header()}
+	if m.search.shown() {
+		rows = append(rows, m.search.View())
+	}
+	if n < len(m.cols) {
+		rows = append(rows, m.indicator())
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, append(rows, board, m.helpView())...)
+}
+
+// columnView renders the column of the lane, marked for scanZones.
+func (m *Board) columnView(lane int, c column) string {
+	return markColumn(lane, c.status, c.View())
+}
+
+// selectedCard returns where the selected card of the focused column was
+// drawn.
+func (m *Board) selectedCard() (zone, bool) {
+	c := m.focusedColumn()
+	if c.list.SelectedItem() == nil {
+		return zone{}, false
+	}
+	lane := 0
+	if m.lanes != nil {
+		lane = m.lane
+	}
+	z, ok := m.zones[zoneID{lane: lane, status: c.status, card: c.list.Index()}]
+	return z, ok
+}
+
+// helpView shows the keys of the sidebar while it has the focus.
+func (m *Board) helpView() string {
+	if m.sidebar.focused {
+		return m.help.ShortHelpView(keys.SidebarHelp())
+	}
+	// the help doesn't count all separators, so it can get too wide on its own
+	groups := keys.FullHelp()
+	for len(groups) > 1 && lipgloss.Width(m.help.FullHelpView(groups)) > m.help.Width {
+		groups = groups[:len(groups)-1]
+	}
+	return m.help.FullHelpView(groups)
 }
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// noticeOf returns the notice the command shows, empty if it shows none.
+func noticeOf(cmd tea.Cmd) string {
+	if cmd == nil {
+		return ""
+	}
+	switch msg := cmd().(type) {
+	case noticeMsg:
+		return string(msg)
+	case tea.BatchMsg:
+		for _, c := range msg {
+			if notice := noticeOf(c); notice != "" {
+				return notice
+			}
+		}
+	}
+	return ""
+}
+
+func TestColumnWindow(t *testing.T) {
+	tests := []struct {
+		name           string
+		width          int
+		count          int
+		focused        int
+		offset         int
+		expectedOffset int
+		expectedN      int
+	}{
+		{"All columns fit", 120, 3, 2, 0, 0, 3},
+		{"Wide terminal shows more columns", 160, 4, 0, 0, 0, 4},
+		{"Never more columns than configured", 300, 3, 0, 0, 0, 3},
+		{"Narrow terminal shows one column", 50, 3, 1, 0, 1, 1},
+		{"Very narrow terminal still shows one column", 10, 3, 0, 0, 0, 1},
+		{"Window follows the focus to the right", 80, 4, 3, 0, 2, 2},
+		{"Window follows the focus to the left", 80, 4, 0, 2, 0, 2},
+		{"Window stays while the focus is visible", 80, 4, 2, 1, 1, 2},
+		{"Offset is clamped after growing", 120, 3, 2, 2, 0, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			offset, n := columnWindow(tt.width, tt.count, tt.focused, tt.offset)
+			if offset != tt.expectedOffset || n != tt.expectedN {
+				t.Errorf("columnWindow() = %d, %d, want %d, %d", offset, n, tt.expectedOffset, tt.expectedN)
+			}
+		})
+	}
+}
+
+func TestParseStatus(t *testing.T) {
+	for _, s := range []status{todo, inProgress, done, never} {
+		parsed, err := parseStatus(s.String())
+		if err != nil || parsed != s {
+			t.Errorf("parseStatus(%q) = %v, %v, want %v", s.String(), parsed, err, s)
+		}
+	}
+	if _, err := parseStatus("blocked"); err == nil {
+		t.Error("expected an error for an unknown column")
+	}
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// doubleClickTime is the longest time between the clicks of a double click.
+const doubleClickTime = 500 * time.Millisecond
+
+// updateMouse handles the mouse on the board. A click selects the card or
+// focuses the column under the pointer, a double click opens the details of
+// the card, the wheel scrolls the column under the pointer and dropping a card
+// on another column moves it like the keys do.
+func (m *Board) updateMouse(msg tea.MouseMsg) tea.Cmd {
+	id, ok := m.zoneAt(msg.X, msg.Y)
+	switch {
+	case msg.Action == tea.MouseActionRelease:
+		dragged := m.dragged
+		m.dragged = nil
+		if dragged == nil || !ok {
+			return nil
+		}
+		return m.drop(*dragged, id)
+	case !ok:
+		return nil
+	case msg.Button == tea.MouseButtonWheelUp, msg.Button == tea.MouseButtonWheelDown:
+		c, _ := m.columnOf(id)
+		if c == nil {
+			return nil
+		}
+		if msg.Button == tea.MouseButtonWheelUp {
+			c.list.CursorUp()
+		} else {
+			c.list.CursorDown()
+		}
+		return nil
+	case msg.Button != tea.MouseButtonLeft || msg.Action != tea.MouseActionPress:
+		return nil
+	}
+
+	m.sidebar.focused = false
+	cmd := m.focusZone(id)
+	if id.card == noCard {
+		return cmd
+	}
+	now := time.Now()
+	double := m.clicked == id && now.Sub(m.clickedAt) < doubleClickTime
+	m.clicked, m.clickedAt = id, now
+	m.dragged = &id
+
+	task, ok := m.focusedColumn().list.SelectedItem().(Task)
+	if double && ok {
+		m.clickedAt = time.Time{}
+		return tea.Batch(cmd, openCardView(NewTaskDetail(task)))
+	}
+	return cmd
+}
+
+// zoneAt returns the card or else the column drawn at the position.
+func (m *Board) zoneAt(x, y int) (zoneID, bool) {
+	var col zoneID
+	found := false
+	for id, z := range m.zones {
+		if !z.contains(x, y) {
+			continue
+		}
+		if id.card != noCard {
+			return id, true
+		}
+		col, found = id, true
+	}
+	return col, found
+}
+
+// columnOf returns the column of the zone and its index in the lane.
+func (m *Board) columnOf(id zoneID) (*column, int) {
+	cols := m.cols
+	if m.lanes != nil && id.lane < len(m.lanes) {
+		cols = m.lanes[id.lane].cols
+	}
+	for i := range cols {
+		if cols[i].status == id.status {
+			return &cols[i], i
+		}
+	}
+	return nil, -1
+}
+
+// focusZone focuses the lane and column of the zone and selects its card.
+func (m *Board) focusZone(id zoneID) tea.Cmd {
+	var cmds []tea.Cmd
+	if m.lanes != nil && id.lane != m.lane {
+		cmds = append(cmds, m.focusLane(id.lane))
+	}
+	if _, i := m.columnOf(id); i >= 0 && i != m.focused {
+		cmds = append(cmds, m.focus(i))
+	}
+	if id.card != noCard {
+		m.focusedColumn().list.Select(id.card)
+	}
+	return tea.Batch(cmds...)
+}
+
+// drop moves the dragged card to the column it was dropped on, with the same
+// transitions as space and enter. Dropped on the same column in another lane
+// it moves to that lane like `{` and `}`. Other moves are refused.
+func (m *Board) drop(dragged, target zoneID) tea.Cmd {
+	from, to := dragged.status, target.status
+	switch {
+	case m.lanes != nil && dragged.lane != target.lane && from == to:
+		return m.moveToLane(target.lane - m.lane)
+	case m.lanes != nil && dragged.lane != target.lane:
+		return notify("Tasks can't change their lane and column at once")
+	case from == to:
+		return nil
+	case from == todo && to == inProgress, from == inProgress && to == todo:
+		return m.guardWIP(to)
+	case (from == todo || from == inProgress) && to == done:
+		return m.guardWIP(done)
+	}
+	return notify(fmt.Sprintf("Tasks can't be moved from %s to %s", from.title(), to.title()))
+}
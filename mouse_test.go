@@ -0,0 +1,167 @@
+package main
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// mouseBoard returns a board of 120x30 drawn on the fake backend.
+func mouseBoard(t *testing.T, fake *fakeBackend) *Board {
+	t.Helper()
+	config = defaultConfig()
+	t.Cleanup(func() { config = defaultConfig() })
+
+	b, err := loadBoard(fake, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	b.Update(tea.WindowSizeMsg{Width: 120, Height: 30})
+	b.View()
+	return b
+}
+
+// press returns a mouse message in the middle of the zone.
+func press(b *Board, id zoneID, button tea.MouseButton, action tea.MouseAction) tea.MouseMsg {
+	z := b.zones[id]
+	return tea.MouseMsg{X: z.x + z.width/2, Y: z.y + z.height/2, Button: button, Action: action}
+}
+
+func TestMouseClick(t *testing.T) {
+	b := mouseBoard(t, newFakeBackend(uiTasks()...))
+
+	b.Update(press(b, zoneID{0, todo, 1}, tea.MouseButtonLeft, tea.MouseActionPress))
+	if b.focused != 0 || b.focusedColumn().list.Index() != 1 {
+		t.Errorf("expected the second card of To Do to be selected, got column %d card %d", b.focused, b.focusedColumn().list.Index())
+	}
+
+	b.Update(press(b, zoneID{0, done, noCard}, tea.MouseButtonLeft, tea.MouseActionPress))
+	if b.focusedColumn().status != done {
+		t.Errorf("expected Done to be focused, got %s", b.focusedColumn().status.title())
+	}
+}
+
+func TestMouseWheel(t *testing.T) {
+	b := mouseBoard(t, newFakeBackend(uiTasks()...))
+
+	b.Update(press(b, zoneID{0, todo, noCard}, tea.MouseButtonWheelDown, tea.MouseActionPress))
+	if index := b.column(todo).list.Index(); index != 1 {
+		t.Errorf("expected the wheel to scroll to the second card, got %d", index)
+	}
+	b.Update(press(b, zoneID{0, todo, noCard}, tea.MouseButtonWheelUp, tea.MouseActionPress))
+	if index := b.column(todo).list.Index(); index != 0 {
+		t.Errorf("expected the wheel to scroll back to the first card, got %d", index)
+	}
+
+	// a zone of a column that isn't on the board anymore is ignored
+	b.zones = map[zoneID]zone{{0, never, noCard}: {x: 0, y: 0, width: 10, height: 10}}
+	b.Update(tea.MouseMsg{X: 5, Y: 5, Button: tea.MouseButtonWheelDown, Action: tea.MouseActionPress})
+}
+
+// opened returns the view the command opens, nil if it opens none.
+func opened(cmd tea.Cmd) tea.Model {
+	if cmd == nil {
+		return nil
+	}
+	switch msg := cmd().(type) {
+	case openMsg:
+		return msg.view
+	case tea.BatchMsg:
+		for _, c := range msg {
+			if view := opened(c); view != nil {
+				return view
+			}
+		}
+	}
+	return nil
+}
+
+func TestMouseDoubleClick(t *testing.T) {
+	b := mouseBoard(t, newFakeBackend(uiTasks()...))
+	card := press(b, zoneID{0, todo, 0}, tea.MouseButtonLeft, tea.MouseActionPress)
+
+	if _, cmd := b.Update(card); opened(cmd) != nil {
+		t.Error("expected a single click not to open a view")
+	}
+	b.Update(tea.MouseMsg{X: card.X, Y: card.Y, Action: tea.MouseActionRelease})
+	_, cmd := b.Update(card)
+	detail, ok := opened(cmd).(*TaskDetail)
+	if !ok || detail.task.description != "Fix the login bug" {
+		t.Errorf("expected the details of the clicked card, got %+v", opened(cmd))
+	}
+}
+
+func TestMouseDrop(t *testing.T) {
+	tests := []struct {
+		name     string
+		from     zoneID
+		to       zoneID
+		expected []string
+	}{
+		{"Start", zoneID{0, todo, 0}, zoneID{0, inProgress, noCard}, []string{"task 2 start"}},
+		{"Stop", zoneID{0, inProgress, 0}, zoneID{0, todo, 1}, []string{"task 3 stop"}},
+		{"Finish", zoneID{0, todo, 0}, zoneID{0, done, noCard}, []string{"task rc.confirmation=no 2 done"}},
+		{"Same column", zoneID{0, todo, 0}, zoneID{0, todo, 1}, nil},
+		{"Reopen", zoneID{0, done, 0}, zoneID{0, todo, noCard}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := newFakeBackend(uiTasks()...)
+			b := mouseBoard(t, fake)
+
+			b.Update(press(b, tt.from, tea.MouseButtonLeft, tea.MouseActionPress))
+			b.Update(press(b, tt.to, tea.MouseButtonNone, tea.MouseActionRelease))
+			requireCommands(t, fake, tt.expected...)
+		})
+	}
+}
+
+func TestMouseDropAfterView(t *testing.T) {
+	fake := newFakeBackend(uiTasks()...)
+	b := mouseBoard(t, fake)
+	a := NewApp(b)
+	card := press(b, zoneID{0, todo, 0}, tea.MouseButtonLeft, tea.MouseActionPress)
+	release := tea.MouseMsg{X: card.X, Y: card.Y, Action: tea.MouseActionRelease}
+
+	// the double click opens the details, they get the release
+	a.Update(card)
+	a.Update(release)
+	_, cmd := a.Update(card)
+	a.Update(openMsg{view: opened(cmd), card: true})
+	a.Update(release)
+	a.Update(closeMsg{})
+
+	column := press(b, zoneID{0, inProgress, noCard}, tea.MouseButtonLeft, tea.MouseActionPress)
+	a.Update(column)
+	a.Update(tea.MouseMsg{X: column.X, Y: column.Y, Action: tea.MouseActionRelease})
+	requireCommands(t, fake)
+}
+
+func TestMouseDropLane(t *testing.T) {
+	tests := []struct {
+		name     string
+		from     zoneID
+		to       zoneID
+		expected []string
+	}{
+		{"Another lane", zoneID{1, todo, 0}, zoneID{0, todo, noCard}, []string{"task rc.confirmation=no 1 modify project:home"}},
+		{"Another lane and column", zoneID{0, inProgress, 0}, zoneID{1, todo, noCard}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := newFakeBackend(uiTasks()...)
+			b := mouseBoard(t, fake)
+			// the lanes are home, work and work.web
+			b.groupBy = "project"
+			b.build(b.tasks())
+			b.resize()
+			b.View()
+
+			b.Update(press(b, tt.from, tea.MouseButtonLeft, tea.MouseActionPress))
+			b.Update(press(b, tt.to, tea.MouseButtonNone, tea.MouseActionRelease))
+			requireCommands(t, fake, tt.expected...)
+		})
+	}
+}
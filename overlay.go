@@ -0,0 +1,84 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/DerTimonius/twkb/styles"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+)
+
+// overlay draws the view centred on the board of the size. The board is
+// dimmed except for the source card, views that don't fit are drawn alone.
+func overlay(board, view string, width, height int, source *zone) string {
+	viewWidth, viewHeight := lipgloss.Size(view)
+	if viewWidth > width || viewHeight > height {
+		return view
+	}
+	x, y := (width-viewWidth)/2, (height-viewHeight)/2
+	viewLines := strings.Split(view, "\n")
+
+	var plain []string
+	for _, spans := range parseANSI(board) {
+		var line strings.Builder
+		for _, s := range spans {
+			line.WriteString(s.text)
+		}
+		plain = append(plain, line.String())
+	}
+
+	lines := make([]string, height)
+	for row := range lines {
+		var line string
+		if row < len(plain) {
+			line = plain[row]
+		}
+		if row < y || row >= y+viewHeight {
+			lines[row] = dim(line, row, 0, width, source)
+			continue
+		}
+		viewLine := viewLines[row-y]
+		lines[row] = dim(line, row, 0, x, source) +
+			viewLine + strings.Repeat(" ", viewWidth-lipgloss.Width(viewLine)) +
+			dim(line, row, x+viewWidth, width, source)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// dim renders the cells from to of the line of the board in the row dimmed,
+// the cells of the source card are highlighted.
+func dim(line string, row, from, to int, source *zone) string {
+	if source == nil || row < source.y || row >= source.y+source.height {
+		return styles.DimmedStyle.Render(cells(line, from, to))
+	}
+	start := min(max(source.x, from), to)
+	end := min(max(source.x+source.width, start), to)
+	return styles.DimmedStyle.Render(cells(line, from, start)) +
+		styles.SourceCardStyle.Render(cells(line, start, end)) +
+		styles.DimmedStyle.Render(cells(line, end, to))
+}
+
+// cells returns the cells from to of the plain line, filled up with spaces.
+// Wide characters that don't fit completely are replaced by spaces.
+func cells(line string, from, to int) string {
+	if from >= to {
+		return ""
+	}
+	var b strings.Builder
+	x := 0
+	for _, r := range line {
+		w := runewidth.RuneWidth(r)
+		switch {
+		case x >= to:
+		case x >= from && x+w <= to:
+			b.WriteRune(r)
+		case x+w > from:
+			b.WriteString(strings.Repeat(" ", min(x+w, to)-max(x, from)))
+		}
+		x += w
+	}
+	if x < to {
+		b.WriteString(strings.Repeat(" ", to-max(x, from)))
+	}
+	return b.String()
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+func TestCells(t *testing.T) {
+	tests := []struct {
+		name     string
+		line     string
+		from, to int
+		expected string
+	}{
+		{"Part of the line", "hello world", 2, 7, "llo w"},
+		{"Filled up with spaces", "hi", 1, 5, "i   "},
+		{"Behind the line", "hi", 4, 6, "  "},
+		{"Empty range", "hello", 3, 3, ""},
+		{"Cut wide character", "a日本", 2, 5, " 本"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := cells(tt.line, tt.from, tt.to); result != tt.expected {
+				t.Errorf("cells(%q, %d, %d) = %q, want %q", tt.line, tt.from, tt.to, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestOverlay(t *testing.T) {
+	lipgloss.SetColorProfile(termenv.Ascii)
+	board := strings.Join([]string{"abcdefgh", "ijklmnop", "qrstuvwx", "yz"}, "\n")
+
+	tests := []struct {
+		name     string
+		view     string
+		expected string
+	}{
+		{"Centred", "12\n34", "abcdefgh\nijk12nop\nqrs34vwx\nyz      "},
+		{"Too high", "1\n2\n3\n4\n5", "1\n2\n3\n4\n5"},
+		{"Too wide", "123456789", "123456789"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := overlay(board, tt.view, 8, 4, nil); result != tt.expected {
+				t.Errorf("expected\n%s\ngot\n%s", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestOverlaySourceCard(t *testing.T) {
+	lipgloss.SetColorProfile(termenv.TrueColor)
+	t.Cleanup(func() { lipgloss.SetColorProfile(termenv.Ascii) })
+
+	result := overlay("abcd\nefgh", "x", 4, 2, &zone{x: 0, y: 1, width: 2, height: 1})
+	lines := parseANSI(result)
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 lines, got %q", result)
+	}
+	// the view is drawn in the first line, the source card in the second
+	source, dimmed := lines[1][0], lines[0][0]
+	if source.text != "ef" || source.style.fg == dimmed.style.fg || !source.style.bold {
+		t.Errorf("expected the source card to be highlighted, got %+v and %+v", source, dimmed)
+	}
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"github.com/DerTimonius/twkb/styles"
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Picker lists items of one type to pick from, like the views or contexts.
+// Enter hands the result of the highlighted item to the board.
+type Picker[T list.Item] struct {
+	list     list.Model
+	help     help.Model
+	helpKeys []key.Binding
+	// picked returns the result of picking the item
+	picked func(T) tea.Msg
+	// toggled marks the item when space is pressed, it is nil for pickers of a
+	// single item
+	toggled func(T)
+}
+
+func newPicker[T list.Item](title string, items []T, delegate list.ItemDelegate, width, height int) *Picker[T] {
+	listItems := make([]list.Item, len(items))
+	for i, item := range items {
+		listItems[i] = item
+	}
+
+	l := list.New(listItems, delegate, width, height)
+	l.Title = title
+	l.Styles.Title = styles.DefaultListTitleStyle
+	l.SetShowHelp(false)
+	l.SetShowStatusBar(false)
+	l.SetFilteringEnabled(false)
+
+	return &Picker[T]{list: l, help: help.New(), helpKeys: keys.PickerHelp()}
+}
+
+// pickerDelegate shows the title and description of the items.
+func pickerDelegate() list.DefaultDelegate {
+	delegate := list.NewDefaultDelegate()
+	delegate.Styles.SelectedTitle = styles.DefaultSelectedTitleStyle
+	delegate.Styles.SelectedDesc = styles.DefaultSelectedDesc
+	return delegate
+}
+
+func (p Picker[T]) Init() tea.Cmd {
+	return nil
+}
+
+func (p Picker[T]) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		p.list.SetHeight(msg.Height / 2)
+	case tea.KeyMsg:
+		item, ok := p.list.SelectedItem().(T)
+		switch {
+		case key.Matches(msg, keys.Enter) && ok:
+			return p, submit(p.picked(item))
+		case key.Matches(msg, keys.TagSelect) && p.toggled != nil && ok:
+			p.toggled(item)
+			return p, nil
+		case key.Matches(msg, keys.Back):
+			return p, closeView
+		case key.Matches(msg, keys.Quit):
+			return p, tea.Quit
+		}
+	}
+	p.list, cmd = p.list.Update(msg)
+	return p, cmd
+}
+
+func (p Picker[T]) View() string {
+	return styles.FormStyle.Render(
+		lipgloss.JoinVertical(lipgloss.Left, p.list.View(), p.help.ShortHelpView(p.helpKeys)),
+	)
+}
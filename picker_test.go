@@ -0,0 +1,53 @@
+package main
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// pick sends the keys to the picker and returns the result of the last one.
+func pick(t *testing.T, p tea.Model, keys ...tea.KeyMsg) tea.Msg {
+	t.Helper()
+	var cmd tea.Cmd
+	for _, k := range keys {
+		p, cmd = p.Update(k)
+	}
+	if cmd == nil {
+		t.Fatal("expected the picker to submit a result")
+	}
+	submitted, ok := cmd().(submitMsg)
+	if !ok {
+		t.Fatalf("the picker returned %T, want a submitMsg", cmd())
+	}
+	return submitted.result
+}
+
+func TestPickers(t *testing.T) {
+	views := map[string]View{"sprint": {Filter: "+sprint"}, "bugs": {Filter: "+bug"}}
+	contexts := []twContext{{name: noContext}, {name: "work", filter: "+work"}, {name: "home"}}
+	tests := []struct {
+		name     string
+		picker   tea.Model
+		expected tea.Msg
+	}{
+		{"The active view is highlighted", NewViewPicker(views, "sprint", 60, 20), viewSelectedMsg{name: "sprint"}},
+		{"Without a view none is highlighted", NewViewPicker(views, "", 60, 20), viewSelectedMsg{name: noView}},
+		{"The active context is highlighted", NewContextPicker(contexts, "work", 60, 20), contextSelectedMsg{name: "work"}},
+		{"Without a context none is highlighted", NewContextPicker(contexts, "", 60, 20), contextSelectedMsg{name: noContext}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := pick(t, tt.picker, tea.KeyMsg{Type: tea.KeyEnter}); result != tt.expected {
+				t.Errorf("picked %+v, want %+v", result, tt.expected)
+			}
+		})
+	}
+
+	// the views are listed by name after none
+	result := pick(t, NewViewPicker(views, "", 60, 20), tea.KeyMsg{Type: tea.KeyDown}, tea.KeyMsg{Type: tea.KeyEnter})
+	if result != (viewSelectedMsg{name: "bugs"}) {
+		t.Errorf("picked %+v, want the bugs view", result)
+	}
+}
@@ -0,0 +1,312 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/DerTimonius/twkb/styles"
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/reflow/truncate"
+)
+
+// sidebarWidth is the width of the project tree next to the board.
+const sidebarWidth = 32
+
+// projectNode is a row of the project tree. Taskwarrior projects are
+// hierarchical, `work.web` is a subproject of `work`.
+type projectNode struct {
+	// name is the full project, empty for the row of all projects
+	name  string
+	depth int
+	// count and done are the tasks of the project and all its subprojects
+	count int
+	done  int
+}
+
+func (n projectNode) label() string {
+	if n.name == "" {
+		return "all projects"
+	}
+	return n.name[strings.LastIndex(n.name, ".")+1:]
+}
+
+// percent is the share of done tasks.
+func (n projectNode) percent() int {
+	if n.count == 0 {
+		return 0
+	}
+	return n.done * 100 / n.count
+}
+
+// inProject reports whether the project is the given one or one of its
+// subprojects.
+func inProject(project, parent string) bool {
+	return project == parent || strings.HasPrefix(project, parent+".")
+}
+
+// projectTree returns the rows of the project tree, every project comes right
+// before its subprojects. Parents without tasks of their own are part of the
+// tree as well. The first row counts all tasks.
+func projectTree(tasks []Task) []projectNode {
+	nodes := map[string]*projectNode{"": {depth: -1}}
+	for _, t := range tasks {
+		if t.status == never {
+			continue
+		}
+		names := []string{""}
+		if t.project != "" {
+			parts := strings.Split(t.project, ".")
+			for i := range parts {
+				names = append(names, strings.Join(parts[:i+1], "."))
+			}
+		}
+		for depth, name := range names {
+			n, ok := nodes[name]
+			if !ok {
+				n = &projectNode{name: name, depth: depth - 1}
+				nodes[name] = n
+			}
+			n.count++
+			if t.status == done {
+				n.done++
+			}
+		}
+	}
+
+	var names []string
+	for name := range nodes {
+		names = append(names, name)
+	}
+	// comparing the parts keeps `work.web` right after `work`, before `work-old`
+	slices.SortFunc(names, func(a, b string) int {
+		return slices.Compare(strings.Split(a, "."), strings.Split(b, "."))
+	})
+	tree := make([]projectNode, len(names))
+	for i, name := range names {
+		tree[i] = *nodes[name]
+	}
+	return tree
+}
+
+// sidebar shows the project tree, while it has the focus the keys move through
+// the projects.
+type sidebar struct {
+	shown   bool
+	focused bool
+	cursor  int
+}
+
+// updateSidebar handles the keys while the sidebar has the focus. Enter shows
+// only the tasks of the selected project and its subprojects.
+func (m *Board) updateSidebar(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	tree := projectTree(m.tasks())
+	m.sidebar.cursor = min(m.sidebar.cursor, len(tree)-1)
+	switch {
+	case key.Matches(msg, keys.Up):
+		m.sidebar.cursor = max(0, m.sidebar.cursor-1)
+	case key.Matches(msg, keys.Down):
+		m.sidebar.cursor = min(len(tree)-1, m.sidebar.cursor+1)
+	case key.Matches(msg, keys.Enter):
+		m.project = tree[m.sidebar.cursor].name
+		m.sidebar.focused = false
+		m.filterColumns()
+		return m, m.resize()
+	case key.Matches(msg, keys.RenameProject):
+		if name := tree[m.sidebar.cursor].name; name != "" {
+			projects := getCompletionWords(m.tw, AllProjectsCmd())
+			for _, n := range tree {
+				projects = append(projects, n.name)
+			}
+			r := NewProjectRename(name, projects)
+			return m, openView(r)
+		}
+	case key.Matches(msg, keys.Back, keys.Projects):
+		m.sidebar = sidebar{cursor: m.sidebar.cursor}
+		return m, m.resize()
+	case key.Matches(msg, keys.Quit):
+		m.quitting = true
+		return m, tea.Quit
+	}
+	return m, nil
+}
+
+// boardWidth is the width left for the columns next to the sidebar.
+func (m *Board) boardWidth() int {
+	if m.sidebar.shown {
+		return m.width - sidebarWidth
+	}
+	return m.width
+}
+
+// match combines the search with the selected project, nil if all tasks are
+// shown.
+func (m *Board) match() func(Task) bool {
+	match := m.search.match()
+	if m.project == "" {
+		return match
+	}
+	return func(t Task) bool {
+		return inProject(t.project, m.project) && (match == nil || match(t))
+	}
+}
+
+// filteredTasks returns the tasks shown with the current search and project.
+func (m *Board) filteredTasks() []Task {
+	match := m.match()
+	var tasks []Task
+	for _, t := range m.tasks() {
+		if match == nil || match(t) {
+			tasks = append(tasks, t)
+		}
+	}
+	return tasks
+}
+
+func (m *Board) sidebarView(height int) string {
+	tree := projectTree(m.tasks())
+	cursor := min(m.sidebar.cursor, len(tree)-1)
+	// the width without the padding and the border
+	width := sidebarWidth - 3
+
+	rows := []string{styles.SidebarTitleStyle.Render("Projects")}
+	// keep the cursor in view
+	visible := max(1, height-2)
+	start := max(0, cursor-visible+1)
+	for i := start; i < min(len(tree), start+visible); i++ {
+		n := tree[i]
+		stats := fmt.Sprintf("%3d %3d%%", n.count, n.percent())
+		label := truncate.StringWithTail(strings.Repeat("  ", max(0, n.depth))+n.label(), uint(width-len(stats)-1), "…")
+		row := label + strings.Repeat(" ", max(1, width-len(stats)-lipgloss.Width(label))) + stats
+
+		style := styles.SidebarItemStyle
+		switch {
+		case m.sidebar.focused && i == cursor:
+			style = styles.SelectedSidebarItemStyle
+		case n.name == m.project:
+			style = styles.ActiveSidebarItemStyle
+		}
+		rows = append(rows, style.Render(row))
+	}
+	return styles.SidebarStyle.Copy().Height(height).Render(lipgloss.JoinVertical(lipgloss.Left, rows...))
+}
+
+// AllProjectsCmd lists the projects of all tasks, including the completed and
+// deleted ones. `task _projects` only knows the pending ones.
+func AllProjectsCmd() []string {
+	return []string{"task", "_unique", "project"}
+}
+
+// RenameProjectCmds renames the project and all its subprojects. A modify sets
+// a single value for the project, so a modify filtered on `project:<from>`
+// would move the subprojects into the renamed project. Instead the project and
+// every subproject get their own modify, matched with `project.is` so that the
+// subprojects keep their suffix. Without subprojects it is a single modify.
+func RenameProjectCmds(from, to string, projects []string) ([][]string, error) {
+	if from == "" {
+		return nil, errors.New("cannot rename the tasks without a project")
+	}
+	if to == "" {
+		return nil, errors.New("the new project cannot be empty")
+	}
+	if err := validateProject(to); err != nil {
+		return nil, err
+	}
+	if to == from {
+		return nil, errNothingToModify
+	}
+
+	if !slices.Contains(projects, from) {
+		projects = append(slices.Clone(projects), from)
+	}
+	slices.Sort(projects)
+
+	var cmds [][]string
+	for _, p := range slices.Compact(projects) {
+		if !inProject(p, from) {
+			continue
+		}
+		cmds = append(cmds, []string{
+			"task", "rc.confirmation=no", "rc.bulk=0",
+			fmt.Sprintf("project.is:%s", p), "modify", fmt.Sprintf("project:%s%s", to, strings.TrimPrefix(p, from)),
+		})
+	}
+	return cmds, nil
+}
+
+// projectRenamedMsg is the result of the rename form, the commands rename the
+// project and its subprojects.
+type projectRenamedMsg struct {
+	from, to string
+	cmds     [][]string
+}
+
+// ProjectRename asks for the new name of a project.
+type ProjectRename struct {
+	help     help.Model
+	input    textinput.Model
+	project  string
+	projects []string
+	err      error
+}
+
+func NewProjectRename(project string, projects []string) *ProjectRename {
+	input := textinput.New()
+	input.SetValue(project)
+	input.Width = 45
+	input.Focus()
+	return &ProjectRename{help: help.New(), input: input, project: project, projects: projects}
+}
+
+func (r ProjectRename) Init() tea.Cmd {
+	return nil
+}
+
+func (r ProjectRename) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, keys.Back):
+			return r, closeView
+		case key.Matches(msg, keys.Submit):
+			var cmds [][]string
+			if cmds, r.err = RenameProjectCmds(r.project, r.name(), r.projects); r.err != nil {
+				return r, nil
+			}
+			return r, submit(projectRenamedMsg{from: r.project, to: r.name(), cmds: cmds})
+		case msg.Type == tea.KeyCtrlC:
+			return r, tea.Quit
+		}
+	}
+	r.input, cmd = r.input.Update(msg)
+	r.err = nil
+	return r, cmd
+}
+
+func (r ProjectRename) name() string {
+	return strings.TrimSpace(r.input.Value())
+}
+
+func (r ProjectRename) View() string {
+	rows := []string{
+		styles.TitleStyle.Render(fmt.Sprintf("Rename the project '%s'", r.project)),
+		styles.InputStyle.Render("Project:     " + r.input.View()),
+	}
+	if r.err != nil {
+		rows = append(rows, styles.FieldErrorStyle.Render(r.err.Error()))
+	} else if cmds, err := RenameProjectCmds(r.project, r.name(), r.projects); err == nil {
+		var preview []string
+		for _, c := range cmds {
+			preview = append(preview, fmt.Sprintf("%s → %s", strings.TrimPrefix(c[3], "project.is:"), strings.TrimPrefix(c[5], "project:")))
+		}
+		rows = append(rows, styles.PreviewStyle.Render(strings.Join(preview, "\n")))
+	}
+	rows = append(rows, strings.Repeat("─", 63), r.help.ShortHelpView(keys.QuickAddHelp()))
+	return styles.FormStyle.Render(lipgloss.JoinVertical(lipgloss.Left, rows...))
+}
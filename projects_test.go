@@ -0,0 +1,133 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestProjectTree(t *testing.T) {
+	tasks := []Task{
+		{project: "work.web.frontend", status: todo},
+		{project: "work.web", status: done},
+		{project: "work-old", status: todo},
+		{project: "home", status: inProgress},
+		{project: "home", status: never},
+		{status: todo},
+	}
+
+	expected := []projectNode{
+		{name: "", depth: -1, count: 5, done: 1},
+		{name: "home", depth: 0, count: 1},
+		{name: "work", depth: 0, count: 2, done: 1},
+		{name: "work.web", depth: 1, count: 2, done: 1},
+		{name: "work.web.frontend", depth: 2, count: 1},
+		{name: "work-old", depth: 0, count: 1},
+	}
+	tree := projectTree(tasks)
+	if len(tree) != len(expected) {
+		t.Fatalf("projectTree() = %v, want %v", tree, expected)
+	}
+	for i := range expected {
+		if tree[i] != expected[i] {
+			t.Errorf("row %d = %+v, want %+v", i, tree[i], expected[i])
+		}
+	}
+	if tree[2].percent() != 50 || tree[1].percent() != 0 {
+		t.Errorf("Expected 50%% done for work and 0%% for home, got %d%% and %d%%", tree[2].percent(), tree[1].percent())
+	}
+	if tree[3].label() != "web" || tree[0].label() != "all projects" {
+		t.Errorf("Unexpected labels %q and %q", tree[3].label(), tree[0].label())
+	}
+}
+
+func TestInProject(t *testing.T) {
+	tests := []struct {
+		project  string
+		parent   string
+		expected bool
+	}{
+		{"work", "work", true},
+		{"work.web", "work", true},
+		{"work-old", "work", false},
+		{"workshop", "work", false},
+		{"work", "work.web", false},
+	}
+
+	for _, tt := range tests {
+		if got := inProject(tt.project, tt.parent); got != tt.expected {
+			t.Errorf("inProject(%q, %q) = %v, want %v", tt.project, tt.parent, got, tt.expected)
+		}
+	}
+}
+
+func TestRenameProjectCmds(t *testing.T) {
+	projects := []string{"home", "work", "work.web", "work.web.frontend", "workshop"}
+
+	cmds, err := RenameProjectCmds("work.web", "work.site", projects)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []string{
+		"task rc.confirmation=no rc.bulk=0 project.is:work.web modify project:work.site",
+		"task rc.confirmation=no rc.bulk=0 project.is:work.web.frontend modify project:work.site.frontend",
+	}
+	if len(cmds) != len(expected) {
+		t.Fatalf("RenameProjectCmds() = %q, want %q", cmds, expected)
+	}
+	for i := range expected {
+		if got := strings.Join(cmds[i], " "); got != expected[i] {
+			t.Errorf("RenameProjectCmds()[%d] = %q, want %q", i, got, expected[i])
+		}
+	}
+
+	// a project only known from the board is renamed as well
+	cmds, err = RenameProjectCmds("errands", "home.errands", projects)
+	if err != nil || len(cmds) != 1 || strings.Join(cmds[0], " ") != "task rc.confirmation=no rc.bulk=0 project.is:errands modify project:home.errands" {
+		t.Errorf("Expected a single rename of errands, got %q, %v", cmds, err)
+	}
+
+	errorTests := []struct {
+		name string
+		from string
+		to   string
+	}{
+		{"No project", "", "work"},
+		{"Empty name", "work", ""},
+		{"Name with spaces", "work", "my work"},
+		{"Name ending with a dot", "work", "job."},
+	}
+	for _, tt := range errorTests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := RenameProjectCmds(tt.from, tt.to, projects); err == nil {
+				t.Errorf("RenameProjectCmds(%q, %q) expected an error", tt.from, tt.to)
+			}
+		})
+	}
+	if _, err := RenameProjectCmds("work", "work", projects); !errors.Is(err, errNothingToModify) {
+		t.Errorf("expected errNothingToModify, got %v", err)
+	}
+}
+
+func TestRenameProjectFailure(t *testing.T) {
+	config = defaultConfig()
+	t.Cleanup(func() { config = defaultConfig() })
+	// the fake backend doesn't know project filters and fails the modify
+	b, err := loadBoard(newFakeBackend(uiTasks()...), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	b.project = "work"
+
+	cmds, err := RenameProjectCmds("work", "job", []string{"work", "work.web"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, cmd := b.Update(projectRenamedMsg{from: "work", to: "job", cmds: cmds})
+	if notice := noticeOf(cmd); !strings.HasPrefix(notice, "Could not rename work") {
+		t.Errorf("expected a notice about the failed rename, got %q", notice)
+	}
+	if b.project != "work" {
+		t.Errorf("expected the project filter to stay on work, got %q", b.project)
+	}
+}
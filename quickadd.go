@@ -0,0 +1,239 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/DerTimonius/twkb/styles"
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// quickAddAttributes are the attributes recognised by the quick add prompt.
+// Taskwarrior accepts abbreviations, e.g. `pro:` for `project:`.
+var quickAddAttributes = []string{"project", "due", "priority", "recur", "until", "wait", "scheduled"}
+
+type attribute struct {
+	name  string
+	value string
+}
+
+// quickAddSpec is the parsed form of a raw taskwarrior `add` line.
+type quickAddSpec struct {
+	description string
+	attributes  []attribute
+	tags        []string
+}
+
+func (q quickAddSpec) get(name string) string {
+	for _, a := range q.attributes {
+		if a.name == name {
+			return a.value
+		}
+	}
+	return ""
+}
+
+// arg is a single word of the quick add input. Quoted phrases are never
+// interpreted as tags or attributes.
+type arg struct {
+	value  string
+	quoted bool
+}
+
+// splitArgs splits the input on whitespace like a shell would, keeping
+// quoted strings together.
+func splitArgs(input string) ([]arg, error) {
+	var args []arg
+	var current arg
+	var value strings.Builder
+	var quote rune
+	inArg := false
+
+	for _, r := range input {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				value.WriteRune(r)
+			}
+		case r == '"' || r == '\'':
+			// only an arg starting with a quote is a quoted phrase, in
+			// project:'my web' the quotes just protect the value
+			if !inArg {
+				current.quoted = true
+			}
+			quote = r
+			inArg = true
+		case r == ' ' || r == '\t':
+			if inArg {
+				current.value = value.String()
+				args = append(args, current)
+				current = arg{}
+				value.Reset()
+				inArg = false
+			}
+		default:
+			value.WriteRune(r)
+			inArg = true
+		}
+	}
+
+	if quote != 0 {
+		return nil, errors.New("unterminated quote")
+	}
+	if inArg {
+		current.value = value.String()
+		args = append(args, current)
+	}
+	return args, nil
+}
+
+// expandAttribute returns the full attribute name for name, which may be an
+// abbreviation of at least two characters.
+func expandAttribute(name string) (string, bool) {
+	if len(name) < 2 {
+		return "", false
+	}
+	var match string
+	for _, attr := range quickAddAttributes {
+		if strings.HasPrefix(attr, name) {
+			if match != "" {
+				return "", false
+			}
+			match = attr
+		}
+	}
+	return match, match != ""
+}
+
+func parseQuickAdd(input string) (quickAddSpec, error) {
+	var spec quickAddSpec
+	args, err := splitArgs(input)
+	if err != nil {
+		return spec, err
+	}
+
+	var words []string
+	for _, a := range args {
+		if a.quoted {
+			words = append(words, a.value)
+			continue
+		}
+		if strings.HasPrefix(a.value, "+") && len(a.value) > 1 {
+			if !slices.Contains(spec.tags, a.value[1:]) {
+				spec.tags = append(spec.tags, a.value[1:])
+			}
+			continue
+		}
+		if name, value, ok := strings.Cut(a.value, ":"); ok {
+			if attr, ok := expandAttribute(name); ok {
+				spec.attributes = append(spec.attributes, attribute{name: attr, value: value})
+				continue
+			}
+		}
+		words = append(words, a.value)
+	}
+	spec.description = strings.Join(words, " ")
+	return spec, nil
+}
+
+// QuickAddCmd builds the add command from the parsed input. The description is
+// passed after `--` as a single argument, so taskwarrior doesn't interpret it.
+func QuickAddCmd(q quickAddSpec) ([]string, error) {
+	if q.description == "" {
+		return []string{}, errors.New("cannot create a task without a description")
+	}
+	if q.get("recur") != "" && q.get("due") == "" {
+		return []string{}, errors.New("cannot create a recurring task without a due date")
+	}
+
+	cmd := []string{"task", "add"}
+	for _, a := range q.attributes {
+		cmd = append(cmd, fmt.Sprintf("%s:%s", a.name, a.value))
+	}
+	for _, t := range q.tags {
+		cmd = append(cmd, fmt.Sprintf("+%s", t))
+	}
+	return append(cmd, "--", q.description), nil
+}
+
+// QuickAdd is a single line prompt accepting raw taskwarrior syntax.
+type QuickAdd struct {
+	help  help.Model
+	input textinput.Model
+	spec  quickAddSpec
+	err   error
+}
+
+func NewQuickAdd() *QuickAdd {
+	input := textinput.New()
+	input.Placeholder = "Fix login bug project:web +bug due:fri priority:H"
+	input.Prompt = "add: "
+	input.Width = 55
+	input.Focus()
+	return &QuickAdd{help: help.New(), input: input}
+}
+
+func (q QuickAdd) Init() tea.Cmd {
+	return nil
+}
+
+func (q QuickAdd) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, keys.Back):
+			return q, closeView
+		case key.Matches(msg, keys.Submit):
+			var cmdStr []string
+			if q.err == nil {
+				cmdStr, q.err = QuickAddCmd(q.spec)
+			}
+			if q.err != nil {
+				return q, nil
+			}
+			task := Task{status: todo, description: q.spec.description, tags: q.spec.tags}
+			return q, submit(taskSavedMsg{task: task, index: APPEND, cmd: cmdStr})
+		case msg.Type == tea.KeyCtrlC:
+			return q, tea.Quit
+		}
+	}
+	q.input, cmd = q.input.Update(msg)
+	q.spec, q.err = parseQuickAdd(q.input.Value())
+	return q, cmd
+}
+
+func (q QuickAdd) View() string {
+	title := styles.TitleStyle.Render("Quick add")
+
+	var preview []string
+	if q.err != nil {
+		preview = append(preview, styles.ErrorStyle.Render(q.err.Error()))
+	}
+	preview = append(preview, fmt.Sprintf("description: %s", q.spec.description))
+	for _, a := range q.spec.attributes {
+		preview = append(preview, fmt.Sprintf("%s: %s", a.name, a.value))
+	}
+	if len(q.spec.tags) > 0 {
+		preview = append(preview, fmt.Sprintf("tags: %s", strings.Join(q.spec.tags, ", ")))
+	}
+
+	return styles.FormStyle.Render(
+		lipgloss.JoinVertical(
+			lipgloss.Left,
+			title,
+			styles.FieldStyle.Render(styles.InputStyle.Render(q.input.View())),
+			styles.PreviewStyle.Render(strings.Join(preview, "\n")),
+			strings.Repeat("─", 63), // Separator line
+			q.help.ShortHelpView(keys.QuickAddHelp()),
+		),
+	)
+}
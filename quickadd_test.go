@@ -0,0 +1,125 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+type quickAddTest struct {
+	expectedErr error
+	name        string
+	input       string
+	expected    string
+}
+
+func TestSplitArgs(t *testing.T) {
+	// quoted args are marked with a trailing *
+	tests := []quickAddTest{
+		{nil, "Plain words", "fix login  bug", "fix|login|bug"},
+		{nil, "Double quotes", `fix "login bug" +web`, "fix|login bug*|+web"},
+		{nil, "Single quotes inside a word", `project:'my web'`, "project:my web"},
+		{errors.New("unterminated quote"), "Unterminated quote", `fix "login`, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := splitArgs(tt.input)
+			if tt.expectedErr != nil {
+				if err == nil || err.Error() != tt.expectedErr.Error() {
+					t.Fatalf("Expected error %v, got %v", tt.expectedErr, err)
+				}
+				return
+			}
+			var values []string
+			for _, a := range result {
+				if a.quoted {
+					a.value += "*"
+				}
+				values = append(values, a.value)
+			}
+			if strings.Join(values, "|") != tt.expected {
+				t.Errorf("splitArgs(%q) = %q, want %q", tt.input, values, tt.expected)
+			}
+		})
+	}
+}
+
+func TestQuickAddCmd(t *testing.T) {
+	validTests := []quickAddTest{
+		{
+			nil,
+			"Only a description",
+			"Fix login bug",
+			"task add -- Fix login bug",
+		},
+		{
+			nil,
+			"Description with attributes and tags",
+			"Fix login bug project:web +bug due:fri priority:H",
+			"task add project:web due:fri priority:H +bug -- Fix login bug",
+		},
+		{
+			nil,
+			"Abbreviated attributes",
+			"pro:web Fix login bug pri:L",
+			"task add project:web priority:L -- Fix login bug",
+		},
+		{
+			nil,
+			"Unknown attributes and quoted phrases stay in the description",
+			`Read https://example.com "due:never" later`,
+			"task add -- Read https://example.com due:never later",
+		},
+		{
+			nil,
+			"Recurring task",
+			"Pay rent due:1st recur:monthly until:now+1yr",
+			"task add due:1st recur:monthly until:now+1yr -- Pay rent",
+		},
+	}
+
+	for _, tt := range validTests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec, err := parseQuickAdd(tt.input)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			result, err := QuickAddCmd(spec)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if strings.Join(result, " ") != tt.expected {
+				t.Errorf("QuickAddCmd(%v) = %q, want %q", tt.input, result, tt.expected)
+			}
+		})
+	}
+
+	errorTests := []quickAddTest{
+		{
+			errors.New("cannot create a task without a description"),
+			"Only attributes",
+			"project:web +bug",
+			"",
+		},
+		{
+			errors.New("cannot create a recurring task without a due date"),
+			"Recurring task without a due date",
+			"Pay rent recur:monthly",
+			"",
+		},
+	}
+
+	for _, tt := range errorTests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec, _ := parseQuickAdd(tt.input)
+			_, err := QuickAddCmd(spec)
+			if err == nil {
+				t.Fatal("Expected an error, but got nil")
+			}
+			if err.Error() != tt.expectedErr.Error() {
+				t.Errorf("Expected error %v, got %v", tt.expectedErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,209 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/DerTimonius/twkb/styles"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// filterTokenRe matches the parts of a taskwarrior filter like `+tag`, `-tag`,
+// `project:x` and `due.before:eow`.
+var filterTokenRe = regexp.MustCompile(`^([+-][\w-]+|[a-zA-Z][\w.]*:.*)$`)
+
+// search is the board wide search. Plain text matches the description, the
+// project, the tags and the annotations of the tasks. Taskwarrior filter
+// expressions get resolved with `task export` instead.
+type search struct {
+	input textinput.Model
+	// uuids are the tasks matching the filter expression
+	uuids map[string]bool
+	err   error
+}
+
+func newSearch() search {
+	input := textinput.New()
+	input.Prompt = "/ "
+	input.Placeholder = "description, project or tag, or a filter like project:x +tag due.before:eow"
+	return search{input: input}
+}
+
+func (s search) query() string {
+	return strings.TrimSpace(s.input.Value())
+}
+
+// shown reports whether the search bar is visible, i.e. while typing and while
+// a search is applied.
+func (s search) shown() bool {
+	return s.input.Focused() || s.query() != ""
+}
+
+// isFilterExpr reports whether the query is a taskwarrior filter expression
+// rather than plain text.
+func isFilterExpr(query string) bool {
+	for _, word := range strings.Fields(query) {
+		if filterTokenRe.MatchString(word) {
+			return true
+		}
+	}
+	return false
+}
+
+// match returns the function deciding which tasks are shown, nil if all tasks
+// are shown.
+func (s search) match() func(Task) bool {
+	query := s.query()
+	switch {
+	case query == "":
+		return nil
+	case isFilterExpr(query):
+		return func(t Task) bool { return s.uuids[t.uuid] }
+	}
+	words := strings.Fields(strings.ToLower(query))
+	return func(t Task) bool { return t.matches(words) }
+}
+
+// terms are the words to highlight on the cards, only plain text gets
+// highlighted.
+func (s search) terms() []string {
+	if isFilterExpr(s.query()) {
+		return nil
+	}
+	return strings.Fields(strings.ToLower(s.query()))
+}
+
+func (s search) View() string {
+	mode := "text"
+	if isFilterExpr(s.query()) {
+		mode = "filter"
+	}
+	view := fmt.Sprintf("%s  %s", s.input.View(), styles.DatePreviewStyle.Render(mode))
+	if s.err != nil {
+		view += " " + styles.ErrorStyle.Render(s.err.Error())
+	}
+	return styles.HeaderStyle.Render(view)
+}
+
+// matches reports whether every word is part of the description, the project,
+// a tag or an annotation of the task. The words need to be lower case.
+func (t Task) matches(words []string) bool {
+	fields := append([]string{t.description, t.project}, t.tags...)
+	fields = append(fields, t.annotations...)
+	for i := range fields {
+		fields[i] = strings.ToLower(fields[i])
+	}
+	for _, word := range words {
+		if !slices.ContainsFunc(fields, func(f string) bool { return strings.Contains(f, word) }) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchIndexes returns the positions of the runes of s that are part of one
+// of the terms, ignoring the case.
+func matchIndexes(s string, terms []string) []int {
+	runes := []rune(strings.ToLower(s))
+	matched := make([]bool, len(runes))
+	for _, term := range terms {
+		term := []rune(term)
+		for i := 0; i+len(term) <= len(runes); i++ {
+			if slices.EqualFunc(runes[i:i+len(term)], term, func(a, b rune) bool {
+				return unicode.ToLower(a) == b
+			}) {
+				for j := range term {
+					matched[i+j] = true
+				}
+			}
+		}
+	}
+
+	var indexes []int
+	for i, m := range matched {
+		if m {
+			indexes = append(indexes, i)
+		}
+	}
+	return indexes
+}
+
+// searchResult are the tasks matching a filter expression.
+type searchResult struct {
+	query string
+	uuids map[string]bool
+	err   error
+}
+
+// searchTasks resolves the filter expression in the background, on top of the
+// filters the board was loaded with. The result is sent back to the board as
+// searchResult.
+func searchTasks(tw Backend, filters []string, query string) tea.Cmd {
+	return func() tea.Msg {
+		output, err := tw.Run(ExportCmd(append(slices.Clone(filters), query)...))
+		if err != nil {
+			return searchResult{query: query, err: fmt.Errorf("invalid filter")}
+		}
+
+		var tasks []struct {
+			UUID string `json:"uuid"`
+		}
+		if err := json.Unmarshal([]byte(output), &tasks); err != nil {
+			return searchResult{query: query, err: err}
+		}
+		uuids := map[string]bool{}
+		for _, t := range tasks {
+			uuids[t.UUID] = true
+		}
+		return searchResult{query: query, uuids: uuids}
+	}
+}
+
+// updateSearch handles the keys while the search bar has the focus. The tasks
+// get filtered while typing, enter keeps the search and esc clears it.
+func (m *Board) updateSearch(msg tea.KeyMsg) tea.Cmd {
+	switch {
+	case key.Matches(msg, keys.Submit):
+		m.search.input.Blur()
+		return m.resize()
+	case key.Matches(msg, keys.Back):
+		return m.clearSearch()
+	}
+
+	query := m.search.query()
+	var cmd tea.Cmd
+	m.search.input, cmd = m.search.input.Update(msg)
+	if m.search.query() == query {
+		return cmd
+	}
+	if isFilterExpr(m.search.query()) {
+		return tea.Batch(cmd, searchTasks(m.tw, m.filters, m.search.query()))
+	}
+	m.search.err = nil
+	m.filterColumns()
+	return cmd
+}
+
+func (m *Board) clearSearch() tea.Cmd {
+	m.search.input.Blur()
+	m.search.input.Reset()
+	m.search.uuids = nil
+	m.search.err = nil
+	m.filterColumns()
+	return m.resize()
+}
+
+// filterColumns applies the search and the selected project to all columns.
+func (m *Board) filterColumns() {
+	match, terms := m.match(), m.search.terms()
+	for _, c := range m.allColumns() {
+		c.filter(match)
+		c.list.SetDelegate(newCardDelegate(config.Card, m.compact).highlight(terms))
+	}
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestIsFilterExpr(t *testing.T) {
+	tests := []struct {
+		query    string
+		expected bool
+	}{
+		{"login bug", false},
+		{"project:web", true},
+		{"+bug", true},
+		{"fix -wip", true},
+		{"due.before:eow", true},
+		{"login - bug", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			if result := isFilterExpr(tt.query); result != tt.expected {
+				t.Errorf("isFilterExpr(%q) = %v, want %v", tt.query, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestTaskMatches(t *testing.T) {
+	task := Task{
+		description: "Fix login bug",
+		project:     "web.auth",
+		tags:        []string{"urgent"},
+		annotations: []string{"Reported by Alice"},
+	}
+	tests := []struct {
+		name     string
+		words    []string
+		expected bool
+	}{
+		{"Description", []string{"login"}, true},
+		{"Project", []string{"auth"}, true},
+		{"Tag", []string{"urg"}, true},
+		{"Annotation", []string{"alice"}, true},
+		{"All words need to match", []string{"login", "alice"}, true},
+		{"A word does not match", []string{"login", "bob"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := task.matches(tt.words); result != tt.expected {
+				t.Errorf("matches(%q) = %v, want %v", tt.words, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestMatchIndexes(t *testing.T) {
+	tests := []struct {
+		name     string
+		s        string
+		terms    []string
+		expected []int
+	}{
+		{"Single term", "Fix login bug", []string{"log"}, []int{4, 5, 6}},
+		{"Ignores the case", "Fix LOGIN", []string{"fix"}, []int{0, 1, 2}},
+		{"Several terms", "Fix bug", []string{"fix", "bug"}, []int{0, 1, 2, 4, 5, 6}},
+		{"Repeated matches", "bug bug", []string{"bug"}, []int{0, 1, 2, 4, 5, 6}},
+		{"Runes after multibyte characters", "Café bar", []string{"bar"}, []int{5, 6, 7}},
+		{"No match", "Fix login", []string{"bar"}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := matchIndexes(tt.s, tt.terms); !slices.Equal(result, tt.expected) {
+				t.Errorf("matchIndexes(%q, %q) = %v, want %v", tt.s, tt.terms, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestColumnFilter(t *testing.T) {
+	config = defaultConfig()
+	c := newColumn(newFakeBackend(), todo, false)
+	c.list.SetItems(convertToListItems([]Task{
+		{description: "Fix login bug", urgency: 2},
+		{description: "Write docs", urgency: 5},
+	}))
+
+	c.filter(func(t Task) bool { return t.matches([]string{"bug"}) })
+	if len(c.list.Items()) != 1 || len(c.tasks()) != 2 {
+		t.Fatalf("Expected 1 of 2 tasks to be shown, got %d of %d", len(c.list.Items()), len(c.tasks()))
+	}
+
+	// tasks that don't match get added to the hidden ones
+	c.Set(APPEND, Task{description: "Review docs", urgency: 1})
+	c.Set(APPEND, Task{description: "Another bug", urgency: 1})
+	if len(c.list.Items()) != 2 || len(c.tasks()) != 4 {
+		t.Fatalf("Expected 2 of 4 tasks to be shown, got %d of %d", len(c.list.Items()), len(c.tasks()))
+	}
+
+	c.filter(nil)
+	var descriptions []string
+	for _, item := range c.list.Items() {
+		descriptions = append(descriptions, item.(Task).description)
+	}
+	expected := []string{"Write docs", "Fix login bug", "Another bug", "Review docs"}
+	if !slices.Equal(descriptions, expected) {
+		t.Errorf("Expected all tasks sorted by urgency, got %q", descriptions)
+	}
+}
@@ -0,0 +1,396 @@
+package main
+
+import (
+	"cmp"
+	"flag"
+	"fmt"
+	"html"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/DerTimonius/twkb/styles"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+	"github.com/muesli/termenv"
+)
+
+// snapshotFormats are the formats of `twkb snapshot`.
+var snapshotFormats = []string{"text", "ansi", "svg", "html"}
+
+// snapshotExtensions select the format when the output is a file.
+var snapshotExtensions = map[string]string{".txt": "text", ".ans": "ansi", ".svg": "svg", ".html": "html", ".htm": "html"}
+
+const (
+	// svgCellWidth and svgLineHeight are the size of a character in the SVG,
+	// for a monospace font of svgFontSize.
+	svgCellWidth  = 8.4
+	svgLineHeight = 18
+	svgFontSize   = 14
+	svgPadding    = 16
+)
+
+// sgr is the style set by the select graphic rendition escape codes.
+type sgr struct {
+	fg, bg    string
+	bold      bool
+	faint     bool
+	italic    bool
+	underline bool
+	reverse   bool
+}
+
+// span is text of a line in one style.
+type span struct {
+	text  string
+	width int
+	style sgr
+}
+
+// renderSnapshot renders the board at the size like the terminal would show
+// it, with all colours of the theme.
+func renderSnapshot(b *Board, width, height int) string {
+	lipgloss.SetColorProfile(termenv.TrueColor)
+	lipgloss.SetHasDarkBackground(true)
+	b.Update(tea.WindowSizeMsg{Width: width, Height: height})
+	return b.View()
+}
+
+// parseANSI splits the rendered lines into spans of the same style. Only the
+// colours and text attributes are kept, other escape codes are dropped.
+func parseANSI(s string) [][]span {
+	var lines [][]span
+	for _, line := range strings.Split(s, "\n") {
+		var spans []span
+		var style sgr
+		var text strings.Builder
+		width := 0
+		flush := func() {
+			if text.Len() > 0 {
+				spans = append(spans, span{text.String(), width, style})
+				text.Reset()
+				width = 0
+			}
+		}
+
+		for i := 0; i < len(line); {
+			if line[i] == '\x1b' && i+1 < len(line) && line[i+1] == '[' {
+				end := i + 2
+				for end < len(line) && (line[end] < 0x40 || line[end] > 0x7e) {
+					end++
+				}
+				if end == len(line) {
+					break
+				}
+				if line[end] == 'm' {
+					next := style.apply(line[i+2 : end])
+					if next != style {
+						flush()
+						style = next
+					}
+				}
+				i = end + 1
+				continue
+			}
+			r, size := utf8.DecodeRuneInString(line[i:])
+			text.WriteRune(r)
+			width += runewidth.RuneWidth(r)
+			i += size
+		}
+		flush()
+		lines = append(lines, spans)
+	}
+	return lines
+}
+
+// apply returns the style after the parameters of an SGR escape code.
+func (s sgr) apply(params string) sgr {
+	codes := strings.Split(params, ";")
+	for i := 0; i < len(codes); i++ {
+		code, _ := strconv.Atoi(codes[i])
+		switch {
+		case code == 0:
+			s = sgr{}
+		case code == 1:
+			s.bold = true
+		case code == 2:
+			s.faint = true
+		case code == 3:
+			s.italic = true
+		case code == 4:
+			s.underline = true
+		case code == 7:
+			s.reverse = true
+		case code == 22:
+			s.bold, s.faint = false, false
+		case code == 23:
+			s.italic = false
+		case code == 24:
+			s.underline = false
+		case code == 27:
+			s.reverse = false
+		case code >= 30 && code <= 37:
+			s.fg = ansiColor(code - 30)
+		case code >= 90 && code <= 97:
+			s.fg = ansiColor(code - 90 + 8)
+		case code >= 40 && code <= 47:
+			s.bg = ansiColor(code - 40)
+		case code >= 100 && code <= 107:
+			s.bg = ansiColor(code - 100 + 8)
+		case code == 39:
+			s.fg = ""
+		case code == 49:
+			s.bg = ""
+		case code == 38 || code == 48:
+			var color string
+			switch {
+			case i+4 < len(codes) && codes[i+1] == "2":
+				r, _ := strconv.Atoi(codes[i+2])
+				g, _ := strconv.Atoi(codes[i+3])
+				b, _ := strconv.Atoi(codes[i+4])
+				color = fmt.Sprintf("#%02x%02x%02x", r, g, b)
+				i += 4
+			case i+2 < len(codes) && codes[i+1] == "5":
+				n, _ := strconv.Atoi(codes[i+2])
+				color = ansiColor(n)
+				i += 2
+			}
+			if code == 38 {
+				s.fg = color
+			} else {
+				s.bg = color
+			}
+		}
+	}
+	return s
+}
+
+// ansiColors are the 16 colours of xterm.
+var ansiColors = []string{
+	"#000000", "#cd0000", "#00cd00", "#cdcd00", "#0000ee", "#cd00cd", "#00cdcd", "#e5e5e5",
+	"#7f7f7f", "#ff0000", "#00ff00", "#ffff00", "#5c5cff", "#ff00ff", "#00ffff", "#ffffff",
+}
+
+// ansiColor returns the colour of the 256 colour palette.
+func ansiColor(n int) string {
+	switch {
+	case n < 0 || n > 255:
+		return ""
+	case n < 16:
+		return ansiColors[n]
+	case n < 232:
+		steps := []int{0, 95, 135, 175, 215, 255}
+		n -= 16
+		return fmt.Sprintf("#%02x%02x%02x", steps[n/36], steps[n/6%6], steps[n%6])
+	}
+	gray := 8 + (n-232)*10
+	return fmt.Sprintf("#%02x%02x%02x", gray, gray, gray)
+}
+
+// colors returns the foreground and background of the style on the theme.
+func (s sgr) colors() (string, string) {
+	fg, bg := cmp.Or(s.fg, styles.Text), s.bg
+	if s.reverse {
+		fg, bg = cmp.Or(s.bg, styles.Base), cmp.Or(s.fg, styles.Text)
+	}
+	return fg, bg
+}
+
+// css returns the inline style of a span in the HTML snapshot.
+func (s sgr) css() string {
+	fg, bg := s.colors()
+	var rules []string
+	if fg != styles.Text {
+		rules = append(rules, "color:"+fg)
+	}
+	if bg != "" {
+		rules = append(rules, "background:"+bg)
+	}
+	if s.bold {
+		rules = append(rules, "font-weight:bold")
+	}
+	if s.faint {
+		rules = append(rules, "opacity:.6")
+	}
+	if s.italic {
+		rules = append(rules, "font-style:italic")
+	}
+	if s.underline {
+		rules = append(rules, "text-decoration:underline")
+	}
+	return strings.Join(rules, ";")
+}
+
+// writeSnapshot writes the rendered board in the format.
+func writeSnapshot(w io.Writer, format, rendered string) error {
+	var err error
+	switch format {
+	case "text":
+		var b strings.Builder
+		for _, line := range parseANSI(rendered) {
+			var text strings.Builder
+			for _, s := range line {
+				text.WriteString(s.text)
+			}
+			b.WriteString(strings.TrimRight(text.String(), " ") + "\n")
+		}
+		_, err = io.WriteString(w, b.String())
+	case "ansi":
+		_, err = io.WriteString(w, rendered+"\n")
+	case "svg":
+		err = writeSVG(w, parseANSI(rendered))
+	case "html":
+		err = writeHTMLSnapshot(w, parseANSI(rendered))
+	default:
+		err = fmt.Errorf("unknown format %q, use %s", format, strings.Join(snapshotFormats, ", "))
+	}
+	return err
+}
+
+func writeHTMLSnapshot(w io.Writer, lines [][]span) error {
+	var b strings.Builder
+	b.WriteString("<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n<title>twkb</title>\n</head>\n")
+	fmt.Fprintf(&b, "<body style=\"background:%s\">\n<pre style=\"color:%s;font-family:monospace;line-height:1.2\">\n", styles.Base, styles.Text)
+	for _, line := range lines {
+		for _, s := range line {
+			// blank text only needs its style for the background
+			_, bg := s.style.colors()
+			if strings.TrimSpace(s.text) == "" && bg == "" && !s.style.underline {
+				b.WriteString(s.text)
+			} else if css := s.style.css(); css != "" {
+				fmt.Fprintf(&b, "<span style=\"%s\">%s</span>", css, html.EscapeString(s.text))
+			} else {
+				b.WriteString(html.EscapeString(s.text))
+			}
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString("</pre>\n</body>\n</html>\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// writeSVG draws every span at its cell, the text length keeps the grid of
+// the terminal even when the font of the viewer is a little wider.
+func writeSVG(w io.Writer, lines [][]span) error {
+	cols := 0
+	for _, line := range lines {
+		width := 0
+		for _, s := range line {
+			width += s.width
+		}
+		cols = max(cols, width)
+	}
+	width := float64(cols)*svgCellWidth + 2*svgPadding
+	height := len(lines)*svgLineHeight + 2*svgPadding
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"%.0f\" height=\"%d\" viewBox=\"0 0 %.0f %d\">\n", width, height, width, height)
+	fmt.Fprintf(&b, "<rect width=\"100%%\" height=\"100%%\" fill=\"%s\"/>\n", styles.Base)
+	fmt.Fprintf(&b, "<g font-family=\"monospace\" font-size=\"%d\" xml:space=\"preserve\">\n", svgFontSize)
+	for i, line := range lines {
+		y := svgPadding + i*svgLineHeight
+		col := 0
+		for _, s := range line {
+			x := svgPadding + float64(col)*svgCellWidth
+			col += s.width
+			fg, bg := s.style.colors()
+			if bg != "" {
+				fmt.Fprintf(&b, "<rect x=\"%.1f\" y=\"%d\" width=\"%.1f\" height=\"%d\" fill=\"%s\"/>\n", x, y, float64(s.width)*svgCellWidth, svgLineHeight, bg)
+			}
+			if strings.TrimSpace(s.text) == "" {
+				continue
+			}
+			var attrs []string
+			if s.style.bold {
+				attrs = append(attrs, `font-weight="bold"`)
+			}
+			if s.style.faint {
+				attrs = append(attrs, `opacity=".6"`)
+			}
+			if s.style.italic {
+				attrs = append(attrs, `font-style="italic"`)
+			}
+			if s.style.underline {
+				attrs = append(attrs, `text-decoration="underline"`)
+			}
+			fmt.Fprintf(&b, "<text x=\"%.1f\" y=\"%d\" fill=\"%s\" textLength=\"%.1f\" lengthAdjust=\"spacingAndGlyphs\"%s>%s</text>\n",
+				x, y+svgLineHeight-4, fg, float64(s.width)*svgCellWidth, prefixSpace(attrs), html.EscapeString(s.text))
+		}
+	}
+	b.WriteString("</g>\n</svg>\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func prefixSpace(attrs []string) string {
+	if len(attrs) == 0 {
+		return ""
+	}
+	return " " + strings.Join(attrs, " ")
+}
+
+// snapshotFormat returns the format of the flag, or of the extension of the
+// output file.
+func snapshotFormat(format, output string) (string, error) {
+	if format == "" {
+		format = "text"
+		if output != "" {
+			ext := strings.ToLower(filepath.Ext(output))
+			var ok bool
+			if format, ok = snapshotExtensions[ext]; !ok {
+				return "", fmt.Errorf("unknown format of %s, use --format %s", output, strings.Join(snapshotFormats, ", "))
+			}
+		}
+	}
+	if !slices.Contains(snapshotFormats, format) {
+		return "", fmt.Errorf("unknown format %q, use %s", format, strings.Join(snapshotFormats, ", "))
+	}
+	return format, nil
+}
+
+// runSnapshot is the `twkb snapshot` subcommand, it renders the board like the
+// TUI without a terminal.
+func runSnapshot(tw Backend, args []string, defaultView string) error {
+	fs := flag.NewFlagSet("snapshot", flag.ExitOnError)
+	format := fs.String("format", "", "snapshot `format`: text, ansi, svg or html, by default from the extension of the output")
+	output := fs.String("output", "", "write to `file` instead of stdout")
+	width := fs.Int("width", 160, "`columns` of the rendered board")
+	height := fs.Int("height", 40, "`lines` of the rendered board")
+	view := fs.String("view", defaultView, "render the named `view` of the config")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: twkb snapshot [--format text|ansi|svg|html] [--output file] [--width n] [--height n] [--view name] [filter]")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	f, err := snapshotFormat(*format, *output)
+	if err != nil {
+		return err
+	}
+	if *width < 20 || *height < 10 {
+		return fmt.Errorf("the snapshot needs at least 20 columns and 10 lines")
+	}
+
+	b, err := loadBoard(tw, *view, strings.Join(fs.Args(), " "))
+	if err != nil {
+		return err
+	}
+	rendered := renderSnapshot(b, *width, *height)
+
+	w := io.Writer(os.Stdout)
+	if *output != "" {
+		file, err := os.Create(*output)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		w = file
+	}
+	return writeSnapshot(w, f, rendered)
+}
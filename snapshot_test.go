@@ -0,0 +1,120 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestParseANSI(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected []span
+	}{
+		{"plain", []span{{"plain", 5, sgr{}}}},
+		{"\x1b[38;2;137;180;250mTo Do\x1b[0m!", []span{{"To Do", 5, sgr{fg: "#89b4fa"}}, {"!", 1, sgr{}}}},
+		{"\x1b[1;7;48;5;196m▰日\x1b[22m x\x1b[m", []span{{"▰日", 3, sgr{bg: "#ff0000", bold: true, reverse: true}}, {" x", 2, sgr{bg: "#ff0000", reverse: true}}}},
+		{"\x1b[31ma\x1b[39;4mb\x1b[2Kc", []span{{"a", 1, sgr{fg: "#cd0000"}}, {"bc", 2, sgr{underline: true}}}},
+		{"\x1b[90;44m \x1b[0m", []span{{" ", 1, sgr{fg: "#7f7f7f", bg: "#0000ee"}}}},
+	}
+
+	for i, test := range tests {
+		lines := parseANSI(test.input)
+		if len(lines) != 1 {
+			t.Fatalf("Test %d: expected one line, got %d", i+1, len(lines))
+		}
+		if len(lines[0]) != len(test.expected) {
+			t.Errorf("Test %d: expected %v, got %v", i+1, test.expected, lines[0])
+			continue
+		}
+		for j, s := range lines[0] {
+			if s != test.expected[j] {
+				t.Errorf("Test %d: expected %v, got %v", i+1, test.expected[j], s)
+			}
+		}
+	}
+}
+
+func TestAnsiColor(t *testing.T) {
+	tests := map[int]string{1: "#cd0000", 16: "#000000", 21: "#0000ff", 196: "#ff0000", 232: "#080808", 255: "#eeeeee", 256: ""}
+	for n, expected := range tests {
+		if result := ansiColor(n); result != expected {
+			t.Errorf("ansiColor(%d) = %q, want %q", n, result, expected)
+		}
+	}
+}
+
+func TestWriteSnapshot(t *testing.T) {
+	rendered := "\x1b[38;2;203;166;247mtwkb\x1b[0m  \n\x1b[7m<a>\x1b[0m"
+	tests := []struct {
+		format   string
+		expected []string
+	}{
+		{"text", []string{"twkb\n<a>\n"}},
+		{"ansi", []string{rendered + "\n"}},
+		{"html", []string{`<span style="color:#cba6f7">twkb</span>  ` + "\n", `<span style="color:#1e1e2e;background:#cdd6f4">&lt;a&gt;</span>`}},
+		{"svg", []string{`width="82" height="68"`, `<text x="16.0" y="30" fill="#cba6f7" textLength="33.6" lengthAdjust="spacingAndGlyphs">twkb</text>`, `<rect x="16.0" y="34" width="25.2" height="18" fill="#cdd6f4"/>`, `>&lt;a&gt;</text>`}},
+	}
+
+	for _, test := range tests {
+		var b bytes.Buffer
+		if err := writeSnapshot(&b, test.format, rendered); err != nil {
+			t.Fatalf("%s: %v", test.format, err)
+		}
+		for _, expected := range test.expected {
+			if !strings.Contains(b.String(), expected) {
+				t.Errorf("%s: expected %q in\n%s", test.format, expected, b.String())
+			}
+		}
+	}
+
+	if err := writeSnapshot(&bytes.Buffer{}, "png", rendered); err == nil {
+		t.Error("expected an error for an unknown format")
+	}
+}
+
+func TestSnapshotFormat(t *testing.T) {
+	tests := []struct {
+		format   string
+		output   string
+		expected string
+		err      bool
+	}{
+		{"", "", "text", false},
+		{"", "board.svg", "svg", false},
+		{"", "board.HTML", "html", false},
+		{"ansi", "board.txt", "ansi", false},
+		{"", "board.png", "", true},
+		{"pdf", "", "", true},
+	}
+
+	for _, test := range tests {
+		result, err := snapshotFormat(test.format, test.output)
+		if result != test.expected || (err != nil) != test.err {
+			t.Errorf("snapshotFormat(%q, %q) = %q, %v", test.format, test.output, result, err)
+		}
+	}
+}
+
+func TestRenderSnapshot(t *testing.T) {
+	config = defaultConfig()
+	b := NewBoard(newFakeBackend())
+	b.build([]Task{
+		{uuid: "1", description: "Write the docs", project: "work", status: todo},
+		{uuid: "2", description: "Fix the bug", status: inProgress},
+	})
+
+	var out bytes.Buffer
+	if err := writeSnapshot(&out, "text", renderSnapshot(b, 120, 30)); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	if len(lines) != 30 {
+		t.Errorf("expected 30 lines, got %d", len(lines))
+	}
+	for _, expected := range []string{"To Do", "In Progress", "Write the docs", "Fix the bug"} {
+		if !strings.Contains(out.String(), expected) {
+			t.Errorf("expected %q in the snapshot\n%s", expected, out.String())
+		}
+	}
+}
@@ -0,0 +1,298 @@
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/DerTimonius/twkb/styles"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	// burndownDays is the number of days of the burndown chart and of the
+	// tasks completed per day.
+	burndownDays = 14
+	// completedWeeks is the number of weeks of the tasks completed per week.
+	completedWeeks = 8
+	// topRows is the number of projects and tags listed on the dashboard.
+	topRows = 5
+	// burndownHeight is the number of lines of the burndown chart.
+	burndownHeight = 6
+)
+
+// sparks are the bars of a sparkline from low to high.
+var sparks = []rune("▁▂▃▄▅▆▇█")
+
+type countRow struct {
+	name  string
+	count int
+}
+
+// average collects durations, e.g. the cycle time of all done tasks.
+type average struct {
+	total time.Duration
+	count int
+}
+
+func (a *average) add(d time.Duration) {
+	a.total += d
+	a.count++
+}
+
+func (a average) String() string {
+	if a.count == 0 {
+		return "n/a"
+	}
+	return humanizeDuration(a.total / time.Duration(a.count))
+}
+
+// stats are the numbers shown on the dashboard.
+type stats struct {
+	columns  []countRow
+	projects []countRow
+	tags     []countRow
+	// perDay and perWeek are the completed tasks, the oldest come first
+	perDay  []int
+	perWeek []int
+	// wait is the time from entry to start, cycle from start to end and lead
+	// from entry to end
+	wait  average
+	cycle average
+	lead  average
+	// burndown are the open tasks at the end of every day, the last day is today
+	burndown []int
+	overdue  []Task
+	now      time.Time
+}
+
+func startOfDay(t time.Time) time.Time {
+	y, m, d := t.Local().Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.Local)
+}
+
+// startOfWeek returns the monday of the week.
+func startOfWeek(t time.Time) time.Time {
+	day := startOfDay(t)
+	return day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+}
+
+// daysBetween counts the days from a to b, rounding takes care of the days
+// changing the daylight saving time.
+func daysBetween(a, b time.Time) int {
+	return int(startOfDay(b).Sub(startOfDay(a)).Hours()/24 + 0.5)
+}
+
+func newStats(tasks []Task, statuses []status, now time.Time) stats {
+	s := stats{
+		perDay:   make([]int, burndownDays),
+		perWeek:  make([]int, completedWeeks),
+		burndown: make([]int, burndownDays),
+		now:      now,
+	}
+
+	columns := map[status]int{}
+	projects := map[string]int{}
+	tags := map[string]int{}
+	for _, t := range tasks {
+		columns[t.status]++
+		if t.status == never {
+			continue
+		}
+		projects[cmp.Or(t.project, noLane)]++
+		for _, tag := range t.tags {
+			tags[tag]++
+		}
+
+		if start := t.startedAt(); !start.IsZero() && !t.entry.IsZero() {
+			s.wait.add(start.Sub(t.entry))
+		}
+		if t.status == done && !t.end.IsZero() {
+			if day := daysBetween(t.end, now); day >= 0 && day < burndownDays {
+				s.perDay[burndownDays-1-day]++
+			}
+			if week := daysBetween(startOfWeek(t.end), startOfWeek(now)) / 7; week >= 0 && week < completedWeeks {
+				s.perWeek[completedWeeks-1-week]++
+			}
+			if d, ok := t.cycleTime(); ok {
+				s.cycle.add(d)
+			}
+			if d, ok := t.leadTime(); ok {
+				s.lead.add(d)
+			}
+		}
+		if (t.status == todo || t.status == inProgress) && t.dueState(now) == overdue {
+			s.overdue = append(s.overdue, t)
+		}
+	}
+
+	for _, st := range statuses {
+		s.columns = append(s.columns, countRow{st.title(), columns[st]})
+	}
+	s.projects = sortedCounts(projects)
+	s.tags = sortedCounts(tags)
+	slices.SortFunc(s.overdue, func(a, b Task) int { return a.due.Compare(b.due) })
+
+	// deleted tasks leave the burndown just like done ones
+	for i := range s.burndown {
+		dayEnd := startOfDay(now).AddDate(0, 0, i-burndownDays+2)
+		if dayEnd.After(now) {
+			dayEnd = now
+		}
+		for _, t := range tasks {
+			if t.entry.Before(dayEnd) && (t.end.IsZero() || t.end.After(dayEnd)) {
+				s.burndown[i]++
+			}
+		}
+	}
+	return s
+}
+
+// sortedCounts sorts by the count, the names break ties.
+func sortedCounts(counts map[string]int) []countRow {
+	var rows []countRow
+	for name, count := range counts {
+		rows = append(rows, countRow{name, count})
+	}
+	slices.SortFunc(rows, func(a, b countRow) int {
+		return cmp.Or(cmp.Compare(b.count, a.count), cmp.Compare(a.name, b.name))
+	})
+	return rows
+}
+
+// sparkline renders the values as bars of one character each.
+func sparkline(values []int) string {
+	highest := slices.Max(values)
+	var b strings.Builder
+	for _, v := range values {
+		if highest == 0 {
+			b.WriteRune(sparks[0])
+			continue
+		}
+		b.WriteRune(sparks[v*(len(sparks)-1)/highest])
+	}
+	return b.String()
+}
+
+// burndownChart renders the values as vertical bars, the highest value is the
+// top of the chart.
+func burndownChart(values []int, height int) string {
+	highest := max(1, slices.Max(values))
+	var lines []string
+	for level := height; level > 0; level-- {
+		label := "    "
+		if level == height {
+			label = fmt.Sprintf("%3d ", highest)
+		}
+		var b strings.Builder
+		for _, v := range values {
+			// round to the nearest line, but show every open task
+			bar := (v*height + highest/2) / highest
+			if v > 0 {
+				bar = max(1, bar)
+			}
+			if bar >= level {
+				b.WriteString("█ ")
+			} else {
+				b.WriteString("  ")
+			}
+		}
+		lines = append(lines, label+"│"+b.String())
+	}
+	lines = append(lines, "  0 └"+strings.Repeat("─", 2*len(values)))
+	return strings.Join(lines, "\n")
+}
+
+// Dashboard shows the statistics of the tasks on the board.
+type Dashboard struct {
+	stats stats
+}
+
+func NewDashboard(tasks []Task, statuses []status, now time.Time) *Dashboard {
+	return &Dashboard{stats: newStats(tasks, statuses, now)}
+}
+
+func (d Dashboard) Init() tea.Cmd {
+	return nil
+}
+
+func (d Dashboard) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, keys.Back), key.Matches(msg, keys.Stats):
+			return d, closeView
+		case key.Matches(msg, keys.Quit):
+			return d, tea.Quit
+		}
+	}
+	return d, nil
+}
+
+func (d Dashboard) View() string {
+	s := d.stats
+	left := lipgloss.JoinVertical(
+		lipgloss.Left,
+		renderCounts("Column", s.columns, len(s.columns)),
+		"",
+		renderCounts("Project", s.projects, topRows),
+		"",
+		renderCounts("Tag", s.tags, topRows),
+	)
+
+	var overdue strings.Builder
+	fmt.Fprintf(&overdue, "Overdue: %d", len(s.overdue))
+	for _, t := range s.overdue[:min(len(s.overdue), topRows)] {
+		fmt.Fprintf(&overdue, "\n  %-28.28s %12s", t.description, relativeDue(t.due, s.now))
+	}
+
+	right := lipgloss.JoinVertical(
+		lipgloss.Left,
+		fmt.Sprintf("Completed per day   %s %4d", sparkline(s.perDay), sum(s.perDay)),
+		fmt.Sprintf("Completed per week  %-*s %4d", burndownDays, sparkline(s.perWeek), sum(s.perWeek)),
+		"",
+		fmt.Sprintf("%-24s %8s %8s", "Cycle time", "Average", "Tasks"),
+		strings.Repeat("─", 42),
+		fmt.Sprintf("%-24s %8s %8d", "entry → start", s.wait, s.wait.count),
+		fmt.Sprintf("%-24s %8s %8d", "start → end", s.cycle, s.cycle.count),
+		fmt.Sprintf("%-24s %8s %8d", "entry → end (lead)", s.lead, s.lead.count),
+		"",
+		fmt.Sprintf("Burndown of the last %d days", burndownDays),
+		burndownChart(s.burndown, burndownHeight),
+		"",
+		styles.ErrorStyle.Render(overdue.String()),
+	)
+
+	return styles.DashboardStyle.Render(
+		lipgloss.JoinVertical(
+			lipgloss.Left,
+			styles.TitleStyle.Render("Statistics"),
+			lipgloss.JoinHorizontal(lipgloss.Top, lipgloss.NewStyle().Width(42).Render(left), "  ", right),
+		),
+	)
+}
+
+func renderCounts(heading string, rows []countRow, limit int) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%-24s %6s", heading, "Tasks")
+	b.WriteString("\n" + strings.Repeat("─", 31))
+	for _, r := range rows[:min(len(rows), limit)] {
+		fmt.Fprintf(&b, "\n%-24.24s %6d", r.name, r.count)
+	}
+	if len(rows) == 0 {
+		b.WriteString("\nnone")
+	}
+	return b.String()
+}
+
+func sum(values []int) int {
+	total := 0
+	for _, v := range values {
+		total += v
+	}
+	return total
+}
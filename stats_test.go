@@ -0,0 +1,73 @@
+package main
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestNewStats(t *testing.T) {
+	// a friday
+	now := time.Date(2024, 3, 15, 12, 0, 0, 0, time.Local)
+	day := func(d, h int) time.Time { return time.Date(2024, 3, d, h, 0, 0, 0, time.Local) }
+
+	tasks := []Task{
+		{project: "work", tags: []string{"bug"}, status: done, entry: day(13, 9), end: day(15, 9)},
+		{project: "work", tags: []string{"bug", "ui"}, status: done, entry: day(10, 10), end: day(11, 10)},
+		{project: "home", status: done, entry: day(7, 10), end: day(8, 10)},
+		{project: "work.web", status: inProgress, entry: day(12, 12), start: day(14, 12)},
+		{status: todo, entry: day(14, 12), due: day(14, 18)},
+		{project: "home", status: never, entry: day(1, 12), end: day(9, 12)},
+	}
+	s := newStats(tasks, []status{todo, inProgress, done}, now)
+
+	expectedColumns := []countRow{{"To Do", 1}, {"In Progress", 1}, {"Done", 3}}
+	if !slices.Equal(s.columns, expectedColumns) {
+		t.Errorf("columns = %v, want %v", s.columns, expectedColumns)
+	}
+	// deleted tasks are not counted
+	expectedProjects := []countRow{{"work", 2}, {"(none)", 1}, {"home", 1}, {"work.web", 1}}
+	if !slices.Equal(s.projects, expectedProjects) {
+		t.Errorf("projects = %v, want %v", s.projects, expectedProjects)
+	}
+	if expectedTags := []countRow{{"bug", 2}, {"ui", 1}}; !slices.Equal(s.tags, expectedTags) {
+		t.Errorf("tags = %v, want %v", s.tags, expectedTags)
+	}
+
+	perDay := make([]int, burndownDays)
+	perDay[13], perDay[9], perDay[6] = 1, 1, 1
+	if !slices.Equal(s.perDay, perDay) {
+		t.Errorf("perDay = %v, want %v", s.perDay, perDay)
+	}
+	perWeek := make([]int, completedWeeks)
+	perWeek[7], perWeek[6] = 2, 1
+	if !slices.Equal(s.perWeek, perWeek) {
+		t.Errorf("perWeek = %v, want %v", s.perWeek, perWeek)
+	}
+
+	if s.lead.count != 3 || s.lead.total != 4*24*time.Hour {
+		t.Errorf("lead = %v over %d tasks, want 96h over 3 tasks", s.lead.total, s.lead.count)
+	}
+	if s.wait.count != 1 || s.cycle.count != 0 || s.cycle.String() != "n/a" {
+		t.Errorf("Expected a single wait and no cycle time, got %d and %d", s.wait.count, s.cycle.count)
+	}
+
+	if len(s.overdue) != 1 || !s.overdue[0].due.Equal(day(14, 18)) {
+		t.Errorf("Expected the todo task to be overdue, got %v", s.overdue)
+	}
+
+	// today 2 tasks are open, at the end of March 7th the deleted task and the
+	// done task of home were, a day later only the deleted one
+	if s.burndown[13] != 2 || s.burndown[5] != 2 || s.burndown[6] != 1 {
+		t.Errorf("Unexpected burndown %v", s.burndown)
+	}
+}
+
+func TestSparkline(t *testing.T) {
+	if got := sparkline([]int{0, 1, 2, 4}); got != "▁▂▄█" {
+		t.Errorf("sparkline() = %q", got)
+	}
+	if got := sparkline([]int{0, 0}); got != "▁▁" {
+		t.Errorf("sparkline() without values = %q", got)
+	}
+}
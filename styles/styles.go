@@ -15,6 +15,10 @@ const (
 	Sapphire  = "#74c7ec"
 	Yellow    = "#f9e2af"
 	Pink      = "#f896ad"
+	// Base and Text are the background and text of the terminal the theme is
+	// made for, snapshots are drawn with them.
+	Base = "#1e1e2e"
+	Text = "#cdd6f4"
 )
 
 var (
@@ -23,6 +27,11 @@ var (
 			Padding(1).
 			Width(65)
 
+	DashboardStyle = lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).BorderForeground(lipgloss.Color(Blue)).
+			Padding(1).
+			Width(92)
+
 	TitleStyle = lipgloss.NewStyle().
 			Foreground(lipgloss.Color(Blue)).
 			Padding(0, 1).
@@ -36,8 +45,54 @@ var (
 	FieldStyle = lipgloss.NewStyle().
 			MarginBottom(1)
 
+	HeaderStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color(Mauve)).
+			Padding(0, 2)
+
+	ErrorStyle = lipgloss.NewStyle().Foreground(lipgloss.Color(Red))
+
+	PreviewStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color(Sapphire)).
+			PaddingLeft(1).
+			MarginBottom(1)
+
+	SuggestionsStyle = lipgloss.NewStyle().
+				Border(lipgloss.NormalBorder(), false, false, false, true).
+				BorderForeground(lipgloss.Color(Gray)).
+				MarginLeft(14)
+
+	SuggestionStyle         = lipgloss.NewStyle().Foreground(lipgloss.Color(LightBlue)).PaddingLeft(2)
+	SelectedSuggestionStyle = lipgloss.NewStyle().Foreground(lipgloss.Color(Pink))
+
+	FieldErrorStyle = lipgloss.NewStyle().Foreground(lipgloss.Color(Red)).PaddingLeft(2)
+
+	DatePreviewStyle = lipgloss.NewStyle().Foreground(lipgloss.Color(Sapphire)).PaddingLeft(2)
+
 	ColumnBaseStyle = lipgloss.NewStyle().Padding(1, 2)
 
+	IndicatorStyle        = lipgloss.NewStyle().Foreground(lipgloss.Color(Gray))
+	VisibleIndicatorStyle = lipgloss.NewStyle().Foreground(lipgloss.Color(LightBlue))
+	ActiveIndicatorStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color(Blue)).Bold(true)
+
+	ColumnTitleStyle       = lipgloss.NewStyle().PaddingLeft(3)
+	LaneHeaderStyle        = lipgloss.NewStyle().Foreground(lipgloss.Color(LightBlue)).Padding(0, 2)
+	FocusedLaneHeaderStyle = LaneHeaderStyle.Copy().Foreground(lipgloss.Color(Pink)).Bold(true)
+
+	SidebarStyle = lipgloss.NewStyle().
+			Border(lipgloss.NormalBorder(), false, true, false, false).
+			BorderForeground(lipgloss.Color(Gray)).
+			PaddingLeft(2).
+			Width(31)
+	SidebarTitleStyle        = lipgloss.NewStyle().Foreground(lipgloss.Color(Mauve)).MarginBottom(1)
+	SidebarItemStyle         = lipgloss.NewStyle().Foreground(lipgloss.Color(LightBlue))
+	SelectedSidebarItemStyle = lipgloss.NewStyle().Foreground(lipgloss.Color(Pink)).Bold(true)
+	ActiveSidebarItemStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color(Green))
+
+	// DimmedStyle draws the board behind forms and confirmations, the card
+	// they act on stays visible in SourceCardStyle.
+	DimmedStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color(Gray))
+	SourceCardStyle = lipgloss.NewStyle().Foreground(lipgloss.Color(Pink)).Bold(true)
+
 	ConfirmationStyle = lipgloss.NewStyle().Border(lipgloss.DoubleBorder()).BorderForeground(lipgloss.Color(Blue)).Padding(1).Width(75).AlignHorizontal(lipgloss.Center).Foreground(lipgloss.Color(Red))
 
 	ItemStyle              = lipgloss.NewStyle().PaddingLeft(4)
@@ -52,8 +107,30 @@ var (
 	DefaultSelectedDesc = DefaultSelectedTitleStyle.Copy().
 				Foreground(lipgloss.Color(Pink))
 
+	CardStyle         = lipgloss.NewStyle().PaddingLeft(2)
+	SelectedCardStyle = lipgloss.NewStyle().
+				Border(lipgloss.NormalBorder(), false, false, false, true).
+				BorderForeground(lipgloss.Color(Pink)).
+				PaddingLeft(1)
+
+	CardTitleStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color(Flamingo))
+	ProjectBadgeStyle = lipgloss.NewStyle().
+				Background(lipgloss.Color(Mauve)).
+				Foreground(lipgloss.Color(Gray)).
+				Padding(0, 1)
+	TagPillStyle      = lipgloss.NewStyle().Foreground(lipgloss.Color(Sapphire))
+	DueChipStyle      = lipgloss.NewStyle().Foreground(lipgloss.Color(Yellow))
+	PriorityChipStyle = lipgloss.NewStyle().Foreground(lipgloss.Color(Maroon))
+	TrackedChipStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color(Green))
+	AgeChipStyle      = lipgloss.NewStyle().Foreground(lipgloss.Color(Gray))
+	IdleChipStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color(Red)).Bold(true)
+	UrgencyBarStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color(Green))
+
 	DefaultListTitleStyle = lipgloss.NewStyle().
 				Background(lipgloss.Color(Blue)).
 				Foreground(lipgloss.Color(Gray)).
 				Padding(0, 1)
+
+	AtLimitTitleStyle   = DefaultListTitleStyle.Copy().Background(lipgloss.Color(Peach))
+	OverLimitTitleStyle = DefaultListTitleStyle.Copy().Background(lipgloss.Color(Red))
 )
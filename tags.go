@@ -0,0 +1,225 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/DerTimonius/twkb/styles"
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// tagItem is a tag listed in the TagPicker.
+type tagItem string
+
+// implement the list.Item interface
+func (t tagItem) FilterValue() string {
+	return string(t)
+}
+
+// tagItemDelegate renders the tags like the block form, the tags of the task
+// are marked.
+type tagItemDelegate struct {
+	selected map[string]bool
+}
+
+func (d tagItemDelegate) Height() int                             { return 1 }
+func (d tagItemDelegate) Spacing() int                            { return 0 }
+func (d tagItemDelegate) Update(_ tea.Msg, _ *list.Model) tea.Cmd { return nil }
+func (d tagItemDelegate) Render(w io.Writer, m list.Model, index int, listItem list.Item) {
+	tag, ok := listItem.(tagItem)
+	if !ok {
+		return
+	}
+
+	str := "#" + string(tag)
+	switch {
+	case d.selected[string(tag)]:
+		str = styles.BlockSelectedItemStyle.Copy().Foreground(lipgloss.Color(styles.Green)).Render("* " + str)
+	case index == m.Index():
+		str = styles.BlockSelectedItemStyle.Render("> " + str)
+	default:
+		str = styles.ItemStyle.Render(str)
+	}
+	fmt.Fprint(w, str)
+}
+
+// tagsMsg is the result of the tag picker and input, the tags replace the
+// ones of the task.
+type tagsMsg struct {
+	index int
+	task  Task
+	tags  []string
+}
+
+// TagPicker toggles the tags of a task between all tags in use. In remove
+// mode only the tags of the task are listed and enter removes the highlighted
+// one.
+type TagPicker = Picker[tagItem]
+
+func NewTagPicker(t Task, index int, tags []string, remove bool, width, height int) *TagPicker {
+	if remove {
+		tags = t.tags
+	}
+	tags = slices.Concat(tags, t.tags)
+	slices.Sort(tags)
+
+	var items []tagItem
+	for _, tag := range slices.Compact(tags) {
+		// taskwarrior lists virtual tags like ACTIVE as well
+		if slices.Contains(virtualTags, tag) {
+			continue
+		}
+		items = append(items, tagItem(tag))
+	}
+
+	selected := map[string]bool{}
+	for _, tag := range t.tags {
+		selected[tag] = true
+	}
+	result := func() tea.Msg {
+		return tagsMsg{index: index, task: t, tags: pickedTags(t, items, selected)}
+	}
+
+	if remove {
+		p := newPicker(fmt.Sprintf("Remove a tag of '%s'", t.description), items, tagItemDelegate{selected: selected}, width, height)
+		p.picked = func(tag tagItem) tea.Msg {
+			delete(selected, string(tag))
+			return result()
+		}
+		return p
+	}
+
+	p := newPicker(fmt.Sprintf("Tags of '%s'", t.description), items, tagItemDelegate{selected: selected}, width, height)
+	p.helpKeys = keys.TagHelp()
+	p.picked = func(tagItem) tea.Msg { return result() }
+	p.toggled = func(tag tagItem) {
+		if selected[string(tag)] {
+			delete(selected, string(tag))
+		} else {
+			selected[string(tag)] = true
+		}
+	}
+	return p
+}
+
+// pickedTags returns the selected tags, the tags the task keeps stay in their
+// order.
+func pickedTags(t Task, items []tagItem, selected map[string]bool) []string {
+	var tags []string
+	for _, tag := range t.tags {
+		if selected[tag] {
+			tags = append(tags, tag)
+		}
+	}
+	for _, item := range items {
+		if tag := string(item); selected[tag] && !slices.Contains(tags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// TagInput adds new tags to a task, the tags in use are suggested.
+type TagInput struct {
+	help       help.Model
+	input      textinput.Model
+	completion *completion
+	task       Task
+	index      int
+	err        error
+}
+
+func NewTagInput(t Task, tags []string) *TagInput {
+	input := textinput.New()
+	input.Placeholder = "bug urgent"
+	input.Width = 45
+	input.Focus()
+
+	var candidates []string
+	for _, tag := range tags {
+		if !slices.Contains(t.tags, tag) && !slices.Contains(virtualTags, tag) {
+			candidates = append(candidates, tag)
+		}
+	}
+	return &TagInput{help: help.New(), input: input, completion: newCompletion(candidates, true), task: t}
+}
+
+func (i TagInput) Init() tea.Cmd {
+	return nil
+}
+
+func (i TagInput) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, keys.NextSuggestion):
+			i.completion.next()
+			return i, nil
+		case key.Matches(msg, keys.PrevSuggestion):
+			i.completion.prev()
+			return i, nil
+		case key.Matches(msg, keys.Tab):
+			value, _ := i.completion.accept(i.input.Value())
+			i.input.SetValue(value)
+			i.input.CursorEnd()
+			i.completion.update(value)
+			return i, nil
+		case key.Matches(msg, keys.Submit):
+			i.err = validateTags(i.input.Value())
+			if i.err == nil && len(strings.Fields(i.input.Value())) == 0 {
+				i.err = errors.New("enter at least one tag")
+			}
+			if i.err != nil {
+				return i, nil
+			}
+			return i, submit(tagsMsg{index: i.index, task: i.task, tags: i.Tags()})
+		case key.Matches(msg, keys.Back):
+			return i, closeView
+		case msg.Type == tea.KeyCtrlC:
+			return i, tea.Quit
+		}
+	}
+	i.input, cmd = i.input.Update(msg)
+	i.completion.update(i.input.Value())
+	i.err = nil
+	return i, cmd
+}
+
+func (i TagInput) View() string {
+	rows := []string{
+		styles.TitleStyle.Render(fmt.Sprintf("Add tags to '%s'", i.task.description)),
+		styles.InputStyle.Render("Tags:        " + i.input.View()),
+	}
+	if view := i.completion.View(); view != "" {
+		rows = append(rows, styles.SuggestionsStyle.Render(view))
+	}
+	if i.err != nil {
+		rows = append(rows, styles.FieldErrorStyle.Render(i.err.Error()))
+	}
+	rows = append(rows, strings.Repeat("─", 63), i.help.ShortHelpView(keys.TagInputHelp()))
+	return styles.FormStyle.Render(lipgloss.JoinVertical(lipgloss.Left, rows...))
+}
+
+// Tags returns the tags of the task with the new ones added.
+func (i TagInput) Tags() []string {
+	return slices.Concat(i.task.tags, strings.Fields(i.input.Value()))
+}
+
+// tagsInUse returns the tags known to taskwarrior and the ones on the board.
+func tagsInUse(tw Backend, tasks []Task) []string {
+	tags := getCompletionWords(tw, TagsCmd())
+	for _, t := range tasks {
+		tags = append(tags, t.tags...)
+	}
+	slices.Sort(tags)
+	return slices.Compact(tags)
+}
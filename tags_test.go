@@ -0,0 +1,47 @@
+package main
+
+import (
+	"slices"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestTagPickerTags(t *testing.T) {
+	task := Task{id: 3, tags: []string{"home", "errand"}}
+	p := NewTagPicker(task, 0, []string{"work", "ACTIVE", "home"}, false, 60, 20)
+
+	var listed []string
+	for _, item := range p.list.Items() {
+		listed = append(listed, string(item.(tagItem)))
+	}
+	if want := []string{"errand", "home", "work"}; !slices.Equal(listed, want) {
+		t.Errorf("listed tags = %v, want %v", listed, want)
+	}
+
+	// unselect home and select work
+	down, space := tea.KeyMsg{Type: tea.KeyDown}, tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}}
+	result, ok := pick(t, p, down, space, down, space, tea.KeyMsg{Type: tea.KeyEnter}).(tagsMsg)
+	if want := []string{"errand", "work"}; !ok || !slices.Equal(result.tags, want) {
+		t.Errorf("picked tags = %v, want %v", result.tags, want)
+	}
+
+	remove := NewTagPicker(task, 0, []string{"work"}, true, 60, 20)
+	if got := len(remove.list.Items()); got != 2 {
+		t.Errorf("remove mode lists %d tags, want only the 2 of the task", got)
+	}
+}
+
+func TestTagPickerSubmit(t *testing.T) {
+	task := Task{id: 3, tags: []string{"home", "errand"}}
+	p := NewTagPicker(task, 1, nil, true, 60, 20)
+
+	result, ok := pick(t, p, tea.KeyMsg{Type: tea.KeyEnter}).(tagsMsg)
+	if !ok {
+		t.Fatalf("the result is %T, want a tagsMsg", result)
+	}
+	// the highlighted tag is removed
+	if result.index != 1 || result.task.id != 3 || !slices.Equal(result.tags, []string{"home"}) {
+		t.Errorf("result = %+v, want the task at index 1 with the tag home", result)
+	}
+}
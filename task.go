@@ -1,140 +1,226 @@
 package main
 
 import (
-	"bytes"
+	"errors"
 	"fmt"
 	"log"
+	"maps"
 	"os"
-	"os/exec"
 	"slices"
 	"strconv"
 	"strings"
+	"time"
 )
 
 type Task struct {
-	description string
-	uuid        string
-	start       string
-	modified    string
-	project     string
-	due         string
-	tags        []string
-	status      status
-	id          int
-	urgency     float64
-	blocked     bool
-	recurring   bool
-}
-
-func (t *Task) StartStop() {
+	description   string
+	uuid          string
+	start         time.Time
+	modified      string
+	entry         time.Time
+	end           time.Time
+	project       string
+	due           time.Time
+	recur         string
+	priority      string
+	until         time.Time
+	wait          time.Time
+	scheduled     time.Time
+	tags          []string
+	udas          map[string]string
+	annotations   []string
+	status        status
+	id            int
+	urgency       float64
+	blocked       bool
+	recurring     bool
+	tracked       time.Duration
+	trackedToday  time.Duration
+	trackingSince time.Time
+	// firstTracked is the start of the first timewarrior interval
+	firstTracked time.Time
+}
+
+func (t *Task) StartStop(tw Backend) {
 	// don't start the task when it is blocked
 	if t.blocked {
 		return
 	}
 	if t.status == inProgress {
-		cmdStr, err := StopCmd(t)
-		if err != nil {
-			fmt.Println(err)
-			os.Exit(1)
-		}
+		t.Stop(tw)
+		return
+	}
 
-		cmd := exec.Command(cmdStr[0], cmdStr[1:]...)
-		err = cmd.Run()
-		if err != nil {
-			log.Fatal(err)
-		}
+	cmdStr, err := StartCmd(t)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
 
-		t.status = todo
-	} else {
-		cmdStr, err := StartCmd(t)
-		if err != nil {
-			fmt.Println(err)
-			os.Exit(1)
-		}
+	_, err = tw.Run(cmdStr)
+	if err != nil {
+		log.Fatal(err)
+	}
 
-		cmd := exec.Command(cmdStr[0], cmdStr[1:]...)
-		err = cmd.Run()
-		if err != nil {
-			log.Fatal(err)
-		}
+	t.status = inProgress
+	t.start = time.Now()
+	t.startTracking(t.start)
+	t.UpdateUrgency(tw)
+}
 
-		t.status = inProgress
+// Stop stops the task, unlike starting it this is fine for blocked tasks.
+func (t *Task) Stop(tw Backend) {
+	cmdStr, err := StopCmd(t)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
 	}
-	t.UpdateUrgency()
+
+	_, err = tw.Run(cmdStr)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	t.status = todo
+	t.start = time.Time{}
+	t.stopTracking(time.Now())
+	t.UpdateUrgency(tw)
 }
 
-func (t *Task) Finish() {
+func (t *Task) Finish(tw Backend) {
 	cmdStr, err := DoneCmd(t)
 	if err != nil {
 		fmt.Println(err)
 		os.Exit(1)
 	}
 
-	cmd := exec.Command(cmdStr[0], cmdStr[1:]...)
-	err = cmd.Run()
+	_, err = tw.Run(cmdStr)
 	if err != nil {
 		log.Fatal(err)
 	}
 
 	t.status = done
+	t.end = time.Now()
+	t.stopTracking(t.end)
 }
 
-func (t *Task) Delete() {
+func (t *Task) Delete(tw Backend) {
 	cmdStr, err := DeleteCmd(t)
 	if err != nil {
 		fmt.Println(err)
 		os.Exit(1)
 	}
 
-	cmd := exec.Command(cmdStr[0], cmdStr[1:]...)
-	err = cmd.Run()
+	_, err = tw.Run(cmdStr)
 	if err != nil {
 		log.Fatal(err)
 	}
 
 	t.status = never
+	t.end = time.Now()
 }
 
-func (t Task) ModifyTask(f *TaskForm) Task {
-	cmdStr, err := ModifyCmd(t, f)
+// addTask runs the add command and loads the new task from taskwarrior to get
+// the resolved dates of its attributes. Until then the task of the form is
+// shown.
+func addTask(tw Backend, cmdStr []string, fallback Task) Task {
+	out, err := tw.Run(cmdStr)
 	if err != nil {
 		fmt.Println(err)
 		os.Exit(1)
 	}
 
-	cmd := exec.Command(cmdStr[0], cmdStr[1:]...)
-	err = cmd.Run()
+	id, err := extractId(out)
 	if err != nil {
-		log.Fatal(err)
+		fmt.Println(err)
+		os.Exit(1)
 	}
 
-	if f.description.Value() != "" && f.description.Value() != t.description {
-		t.description = f.description.Value()
+	if tasks := getFromTW(tw, fmt.Sprint(id)); len(tasks) == 1 {
+		return tasks[0]
 	}
+	fallback.id = id
+	fallback.UpdateUrgency(tw)
+	return fallback
+}
 
-	if f.due.Value() != "" && f.due.Value() != t.due {
-		t.due = f.due.Value()
+// modifyTask runs the modify command, the task already has the new values.
+func modifyTask(tw Backend, cmdStr []string, t Task) Task {
+	if _, err := tw.Run(cmdStr); err != nil {
+		log.Fatal(err)
 	}
+	return t
+}
 
-	if f.project.Value() != t.project {
-		t.project = f.project.Value()
+// SetTags changes the tags of the task to the wanted ones.
+func (t Task) SetTags(tw Backend, wanted []string) Task {
+	cmdStr, err := TagCmd(t, wanted)
+	if errors.Is(err, errNothingToModify) {
+		return t
+	}
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
 	}
 
-	if f.label.Value() != "" {
-		formValues := strings.Split(f.label.Value(), " ")
-		addedLabels := []string{}
-		currLabels := t.tags
-		for _, label := range formValues {
-			idx := slices.Index(currLabels, label)
-			if idx == -1 {
-				addedLabels = append(addedLabels, label)
-			} else {
-				currLabels = slices.Delete(currLabels, idx, idx+1)
-			}
+	_, err = tw.Run(cmdStr)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	t.tags = uniqueTags(wanted)
+	t.UpdateUrgency(tw)
+	return t
+}
+
+// uniqueTags returns the tags without duplicates, in their order.
+func uniqueTags(tags []string) []string {
+	var unique []string
+	for _, tag := range tags {
+		if !slices.Contains(unique, tag) {
+			unique = append(unique, tag)
 		}
-		t.tags = addedLabels
 	}
+	return unique
+}
 
+// MoveToLane changes the grouping attribute of the task to the value of the
+// lane, in memory the new tag becomes the first one to keep it in that lane.
+func (t Task) MoveToLane(tw Backend, groupBy, lane string) Task {
+	cmdStr, err := LaneCmd(t, groupBy, lane)
+	if errors.Is(err, errNothingToModify) {
+		return t
+	}
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	_, err = tw.Run(cmdStr)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	value := lane
+	if lane == noLane {
+		value = ""
+	}
+	switch groupBy {
+	case "project":
+		t.project = value
+	case "priority":
+		t.priority = value
+	case "tag":
+		t.tags = laneTags(t.tags, value)
+	default:
+		udas := maps.Clone(t.udas)
+		if udas == nil {
+			udas = map[string]string{}
+		}
+		udas[groupBy] = value
+		t.udas = udas
+	}
+	t.UpdateUrgency(tw)
 	return t
 }
 
@@ -151,6 +237,9 @@ func (t Task) Title() string {
 	if t.recurring {
 		addMsg = "[RECURRING]"
 	}
+	if !t.trackingSince.IsZero() {
+		addMsg = strings.TrimSpace(fmt.Sprintf("%s ⏱ %s", addMsg, formatTimer(time.Since(t.trackingSince))))
+	}
 	return fmt.Sprintf("%s %s", t.description, addMsg)
 }
 
@@ -164,29 +253,31 @@ func (t Task) Description() string {
 		projectMsg = fmt.Sprintf("Project: %s, ", t.project)
 	}
 	var dueMsg string
-	if t.due != "" {
-		dueMsg = fmt.Sprintf("Due: %s, ", t.due)
+	if !t.due.IsZero() {
+		dueMsg = fmt.Sprintf("Due: %s, ", formatDue(t.due, time.Now(), config.DueFormat))
+	}
+	var trackedMsg string
+	if t.status == inProgress && config.Timewarrior {
+		now := time.Now()
+		trackedMsg = fmt.Sprintf("Tracked: %s (today %s), ", formatDuration(t.TrackedTotal(now)), formatDuration(t.TrackedToday(now)))
 	}
-	return fmt.Sprintf("%s%s%sUrgency: %.1f", projectMsg, tagsMsg, dueMsg, t.urgency)
+	return fmt.Sprintf("%s%s%s%sUrgency: %.1f", projectMsg, tagsMsg, dueMsg, trackedMsg, t.urgency)
 }
 
-func (t *Task) UpdateUrgency() {
+func (t *Task) UpdateUrgency(tw Backend) {
 	var taskId string
 	if t.uuid != "" {
 		taskId = t.uuid
 	} else {
 		taskId = fmt.Sprint(t.id)
 	}
-	cmd := exec.Command("task", taskId, "_urgency")
-	var out bytes.Buffer
-	cmd.Stdout = &out
-	err := cmd.Run()
+	out, err := tw.Run(UrgencyCmd(taskId))
 	if err != nil {
 		t.urgency = 0.0
 		return
 	}
 
-	urgency, e := extractUrgency(out.String())
+	urgency, e := extractUrgency(out)
 	// it's safe to ignore these errors, just set the urgency to 0.0
 	if e != nil {
 		t.urgency = 0.0
@@ -195,7 +286,7 @@ func (t *Task) UpdateUrgency() {
 	t.urgency = urgency
 }
 
-func (t *Task) BlockTasks(tasks *[]Task) {
+func (t *Task) BlockTasks(tw Backend, tasks *[]Task) {
 	// don't block other tasks when it is already finished
 	if t.status == done {
 		return
@@ -207,34 +298,32 @@ func (t *Task) BlockTasks(tasks *[]Task) {
 		os.Exit(1)
 	}
 
-	cmd := exec.Command(cmdStr[0], cmdStr[1:]...)
-	err = cmd.Run()
+	_, err = tw.Run(cmdStr)
 	if err != nil {
 		log.Fatal(err)
 	}
 
 	for i := range *tasks {
 		(*tasks)[i].blocked = true
-		(*tasks)[i].UpdateUrgency()
+		(*tasks)[i].UpdateUrgency(tw)
 	}
-	t.UpdateUrgency()
+	t.UpdateUrgency(tw)
 }
 
-func (t *Task) UnblockTask() {
+func (t *Task) UnblockTask(tw Backend) {
 	cmdStr, err := UnblockCmd(t)
 	if err != nil {
 		fmt.Println(err)
 		os.Exit(1)
 	}
 
-	cmd := exec.Command(cmdStr[0], cmdStr[1:]...)
-	err = cmd.Run()
+	_, err = tw.Run(cmdStr)
 	if err != nil {
 		log.Fatal(err)
 	}
 
 	t.blocked = false
-	t.UpdateUrgency()
+	t.UpdateUrgency(tw)
 }
 
 func extractUrgency(input string) (float64, error) {
@@ -7,6 +7,8 @@ import (
 	"strings"
 )
 
+var errNothingToModify = errors.New("nothing to modify")
+
 func AddCmd(f TaskForm) ([]string, error) {
 	if f.description.Value() == "" {
 		return []string{}, errors.New("cannot create a task without a description")
@@ -16,6 +18,9 @@ func AddCmd(f TaskForm) ([]string, error) {
 	var tags string
 	var recur string
 	var until string
+	var wait string
+	var scheduled string
+	var priority string
 
 	if f.due.Value() != "" {
 		due = fmt.Sprintf("due:%s ", f.due.Value())
@@ -43,11 +48,23 @@ func AddCmd(f TaskForm) ([]string, error) {
 		until = fmt.Sprintf("until:%s ", f.until.Value())
 	}
 
+	if f.wait.Value() != "" {
+		wait = fmt.Sprintf("wait:%s ", f.wait.Value())
+	}
+
+	if f.scheduled.Value() != "" {
+		scheduled = fmt.Sprintf("scheduled:%s ", f.scheduled.Value())
+	}
+
+	if f.priority.Value() != "" {
+		priority = fmt.Sprintf("priority:%s ", f.priority.Value())
+	}
+
 	if recur != "" && due == "" {
 		return []string{}, errors.New("cannot create a recurring task without a due date")
 	}
 
-	str := fmt.Sprintf("task add %s %s%s%s%s%s", f.description.Value(), project, due, tags, recur, until)
+	str := fmt.Sprintf("task add %s %s%s%s%s%s%s%s%s", f.description.Value(), project, due, tags, recur, until, wait, scheduled, priority)
 	return strings.Split(strings.TrimSuffix(str, " "), " "), nil
 }
 
@@ -86,49 +103,43 @@ func ModifyCmd(t Task, f *TaskForm) ([]string, error) {
 
 	var changedLabels []string
 	var changedDescription string
-	var changedDue string
-	var changedProject string
+	var changedAttributes []string
 
 	if f.description.Value() != "" && f.description.Value() != t.description {
 		changedDescription = f.description.Value()
 	}
 
-	if f.due.Value() != "" && f.due.Value() != t.due {
-		changedDue = fmt.Sprintf("due:%s ", f.due.Value())
-	}
-
-	if f.project.Value() != t.project {
-		changedProject = fmt.Sprintf("project:%s ", f.project.Value())
+	// only emit the attributes that changed, an empty value clears the attribute
+	attributes := []struct {
+		name    string
+		current string
+		value   string
+	}{
+		{"project", t.project, f.project.Value()},
+		{"due", formatTWDate(t.due), f.due.Value()},
+		{"recur", t.recur, f.recur.Value()},
+		{"until", formatTWDate(t.until), f.until.Value()},
+		{"wait", formatTWDate(t.wait), f.wait.Value()},
+		{"scheduled", formatTWDate(t.scheduled), f.scheduled.Value()},
+		{"priority", t.priority, f.priority.Value()},
 	}
-
-	if f.label.Value() != "" {
-		formValues := strings.Split(f.label.Value(), " ")
-		addedLabels := []string{}
-		currLabels := t.tags
-		for _, label := range formValues {
-			idx := slices.Index(currLabels, label)
-			if idx == -1 {
-				addedLabels = append(addedLabels, label)
-			} else {
-				currLabels = slices.Delete(currLabels, idx, idx+1)
-			}
-		}
-
-		for _, label := range addedLabels {
-			changedLabels = append(changedLabels, fmt.Sprintf("+%s", label))
+	for _, attr := range attributes {
+		if attr.value != attr.current {
+			changedAttributes = append(changedAttributes, fmt.Sprintf("%s:%s", attr.name, attr.value))
 		}
+	}
 
-		for _, label := range currLabels {
-			changedLabels = append(changedLabels, fmt.Sprintf("-%s", label))
-		}
+	// the labels are the complete set of tags, an empty field removes all tags
+	changedLabels = tagArgs(diffTags(t.tags, strings.Fields(f.label.Value())))
+	if changedDescription == "" && len(changedAttributes) == 0 && len(changedLabels) == 0 {
+		return []string{}, errNothingToModify
 	}
 
 	str := fmt.Sprintf(
-		"task rc.confirmation=no %d modify %s %s%s%s",
+		"task rc.confirmation=no %d modify %s %s %s",
 		t.id,
 		changedDescription,
-		changedProject,
-		changedDue,
+		strings.Join(changedAttributes, " "),
 		strings.Join(changedLabels, " "),
 	)
 	cmdArgs := []string{}
@@ -142,6 +153,91 @@ func ModifyCmd(t Task, f *TaskForm) ([]string, error) {
 	return cmdArgs, nil
 }
 
+// LaneCmd sets the grouping attribute of the task to the value of the lane, the
+// lane of the tasks without a value clears it. For tags the first tag gets
+// replaced, the lane without a tag removes all of them.
+func LaneCmd(t Task, groupBy, lane string) ([]string, error) {
+	if t.id == 0 {
+		return []string{}, errors.New("cannot move a task with ID 0")
+	}
+	value := lane
+	if lane == noLane {
+		value = ""
+	}
+
+	cmd := []string{"task", "rc.confirmation=no", fmt.Sprint(t.id), "modify"}
+	if groupBy != "tag" {
+		return append(cmd, fmt.Sprintf("%s:%s", groupBy, value)), nil
+	}
+	if value == "" {
+		removed := tagArgs(nil, t.tags)
+		if len(removed) == 0 {
+			return []string{}, errNothingToModify
+		}
+		return append(cmd, removed...), nil
+	}
+	var changedTags []string
+	if len(t.tags) > 0 {
+		if t.tags[0] == value {
+			return []string{}, errNothingToModify
+		}
+		changedTags = append(changedTags, fmt.Sprintf("-%s", t.tags[0]))
+	}
+	return append(cmd, append(changedTags, fmt.Sprintf("+%s", value))...), nil
+}
+
+// laneTags returns the tags of a task moved to the lane of the tag, it replaces
+// the first tag. The lane without a tag holds the tasks without tags.
+func laneTags(tags []string, value string) []string {
+	if value == "" {
+		return nil
+	}
+	var rest []string
+	if len(tags) > 0 {
+		rest = tags[1:]
+	}
+	return uniqueTags(append([]string{value}, rest...))
+}
+
+// diffTags returns the tags that need to be added and removed to get from the
+// current to the wanted tags.
+func diffTags(current, wanted []string) (added, removed []string) {
+	for _, tag := range wanted {
+		if !slices.Contains(current, tag) && !slices.Contains(added, tag) {
+			added = append(added, tag)
+		}
+	}
+	for _, tag := range current {
+		if !slices.Contains(wanted, tag) {
+			removed = append(removed, tag)
+		}
+	}
+	return added, removed
+}
+
+func tagArgs(added, removed []string) []string {
+	var args []string
+	for _, tag := range added {
+		args = append(args, fmt.Sprintf("+%s", tag))
+	}
+	for _, tag := range removed {
+		args = append(args, fmt.Sprintf("-%s", tag))
+	}
+	return args
+}
+
+// TagCmd changes the tags of the task to the wanted ones.
+func TagCmd(t Task, wanted []string) ([]string, error) {
+	if t.id == 0 {
+		return []string{}, errors.New("cannot tag a task with ID 0")
+	}
+	args := tagArgs(diffTags(t.tags, wanted))
+	if len(args) == 0 {
+		return []string{}, errNothingToModify
+	}
+	return append([]string{"task", "rc.confirmation=no", fmt.Sprint(t.id), "modify"}, args...), nil
+}
+
 func BlockCmd(t *Task, blocked *[]Task) ([]string, error) {
 	if len(*blocked) == 0 {
 		return []string{}, errors.New("need to select at least 1 task")
@@ -189,3 +285,9 @@ func UnblockCmd(t *Task) ([]string, error) {
 
 	return []string{"task", fmt.Sprint(t.id), "modify", "depends:"}, nil
 }
+
+// UrgencyCmd returns the command printing the urgency of the task with the id
+// or uuid.
+func UrgencyCmd(taskID string) []string {
+	return []string{"task", taskID, "_urgency"}
+}
@@ -2,8 +2,10 @@ package main
 
 import (
 	"errors"
+	"slices"
 	"strings"
 	"testing"
+	"time"
 )
 
 type modifyTest struct {
@@ -76,7 +78,19 @@ func TestAddCmd(t *testing.T) {
 	testForm8.recur.SetValue("monthly")
 	testForm8.until.SetValue("now+1yr")
 
+	testForm9 := newDefaultForm()
+	testForm9.description.SetValue("test the add command")
+	testForm9.wait.SetValue("monday")
+	testForm9.scheduled.SetValue("tomorrow")
+	testForm9.priority.SetValue("H")
+
 	validTests := []formTest{
+		{
+			nil,
+			"Task creation with wait, scheduled and priority",
+			"task add test the add command wait:monday scheduled:tomorrow priority:H",
+			*testForm9,
+		},
 		{
 			nil,
 			"Basic task creation with no label, project or due date",
@@ -335,76 +349,169 @@ func TestDeleteCmd(t *testing.T) {
 }
 
 func TestModifyCmd(t *testing.T) {
-	testForm1 := newDefaultForm()
+	// every test gets its own task, the forms are prefilled like in the TUI
+	baseTask := func() Task {
+		return Task{id: 42, description: "basic task", project: "task-gui", tags: []string{"rust", "cli"}, due: time.Date(2024, 3, 1, 23, 59, 59, 0, time.Local)}
+	}
+
+	testForm1 := NewEditForm(baseTask())
 	testForm1.description.SetValue("test the modify command")
-	testForm1.project.SetValue("task-gui")
 
-	testForm2 := newDefaultForm()
+	testForm2 := NewEditForm(baseTask())
 	testForm2.project.SetValue("twkb")
 
-	testForm3 := newDefaultForm()
+	testForm3 := NewEditForm(baseTask())
+	testForm3.project.SetValue("")
 	testForm3.label.SetValue("go tui")
 
-	testForm4 := newDefaultForm()
+	testForm4 := NewEditForm(baseTask())
 	testForm4.description.SetValue("test the modify command")
 	testForm4.project.SetValue("twkb")
 	testForm4.label.SetValue("go tui")
 	testForm4.due.SetValue("7d")
 
-	testForm5 := newDefaultForm()
+	testForm5 := NewEditForm(baseTask())
 	testForm5.description.SetValue("test the modify command")
 	testForm5.label.SetValue("go tui")
-	testForm5.project.SetValue("task-gui")
 
-	testForm6 := newDefaultForm()
+	testForm6 := NewEditForm(baseTask())
 	testForm6.due.SetValue("eow")
-	testForm6.project.SetValue("task-gui")
 
-	baseTask := Task{id: 42, description: "basic task", project: "task-gui", tags: []string{"rust", "cli"}, due: "eod"}
+	testForm7 := NewEditForm(baseTask())
+	testForm7.due.SetValue("")
+
+	testForm8 := NewEditForm(baseTask())
+	testForm8.recur.SetValue("weekly")
+	testForm8.until.SetValue("now+1yr")
+	testForm8.wait.SetValue("monday")
+	testForm8.scheduled.SetValue("tomorrow")
+	testForm8.priority.SetValue("H")
+
+	scheduledTask := func() Task {
+		t := baseTask()
+		t.priority = "M"
+		t.scheduled = time.Date(2024, 3, 1, 9, 0, 0, 0, time.Local)
+		return t
+	}
+
+	testForm9 := NewEditForm(scheduledTask())
+	testForm9.priority.SetValue("")
+	testForm9.scheduled.SetValue("")
+
+	testForm10 := NewEditForm(scheduledTask())
+	testForm10.description.SetValue("test the modify command")
+
+	testForm11 := NewEditForm(baseTask())
+	testForm11.label.SetValue("rust go")
+
+	testForm12 := NewEditForm(baseTask())
+	testForm12.label.SetValue("")
+
+	testForm13 := NewEditForm(baseTask())
+	testForm13.label.SetValue("cli go go rust")
+
+	testForm14 := NewEditForm(baseTask())
+	testForm14.description.SetValue("please modify")
 
 	validTests := []modifyTest{
 		{
 			nil,
 			"Modify only the description",
 			"task rc.confirmation=no 42 modify test the modify command",
-			baseTask,
+			baseTask(),
 			*testForm1,
 		},
+		{
+			nil,
+			"Description ending in modify",
+			"task rc.confirmation=no 42 modify please modify",
+			baseTask(),
+			*testForm14,
+		},
 		{
 			nil,
 			"Modify only the project",
 			"task rc.confirmation=no 42 modify project:twkb",
-			baseTask,
+			baseTask(),
 			*testForm2,
 		},
 		{
 			nil,
 			"Remove the project and modify the labels",
 			"task rc.confirmation=no 42 modify project: +go +tui -rust -cli",
-			baseTask,
+			baseTask(),
 			*testForm3,
 		},
 		{
 			nil,
 			"Modify every aspect of the task",
 			"task rc.confirmation=no 42 modify test the modify command project:twkb due:7d +go +tui -rust -cli",
-			baseTask,
+			baseTask(),
 			*testForm4,
 		},
 		{
 			nil,
 			"Modify the description and the labels",
 			"task rc.confirmation=no 42 modify test the modify command +go +tui -rust -cli",
-			baseTask,
+			baseTask(),
 			*testForm5,
 		},
 		{
 			nil,
 			"Modify only the due date",
 			"task rc.confirmation=no 42 modify due:eow",
-			baseTask,
+			baseTask(),
 			*testForm6,
 		},
+		{
+			nil,
+			"Remove the due date",
+			"task rc.confirmation=no 42 modify due:",
+			baseTask(),
+			*testForm7,
+		},
+		{
+			nil,
+			"Set recurrence, until, wait, scheduled and priority",
+			"task rc.confirmation=no 42 modify recur:weekly until:now+1yr wait:monday scheduled:tomorrow priority:H",
+			baseTask(),
+			*testForm8,
+		},
+		{
+			nil,
+			"Remove scheduled and priority",
+			"task rc.confirmation=no 42 modify scheduled: priority:",
+			scheduledTask(),
+			*testForm9,
+		},
+		{
+			nil,
+			"Unchanged prefilled dates are not emitted",
+			"task rc.confirmation=no 42 modify test the modify command",
+			scheduledTask(),
+			*testForm10,
+		},
+		{
+			nil,
+			"Keep a label, add one and remove one",
+			"task rc.confirmation=no 42 modify +go -cli",
+			baseTask(),
+			*testForm11,
+		},
+		{
+			nil,
+			"Remove all labels",
+			"task rc.confirmation=no 42 modify -rust -cli",
+			baseTask(),
+			*testForm12,
+		},
+		{
+			nil,
+			"Reordered and duplicated labels",
+			"task rc.confirmation=no 42 modify +go",
+			baseTask(),
+			*testForm13,
+		},
 	}
 
 	for _, tt := range validTests {
@@ -417,6 +524,7 @@ func TestModifyCmd(t *testing.T) {
 	}
 
 	errorTestForm := newDefaultForm()
+	unchangedForm := NewEditForm(baseTask())
 	errorTests := []modifyTest{
 		{
 			errors.New("cannot modify a task with ID 0"),
@@ -425,6 +533,13 @@ func TestModifyCmd(t *testing.T) {
 			Task{id: 0},
 			*errorTestForm,
 		},
+		{
+			errNothingToModify,
+			"Nothing changed",
+			"",
+			baseTask(),
+			*unchangedForm,
+		},
 	}
 
 	for _, tt := range errorTests {
@@ -440,6 +555,41 @@ func TestModifyCmd(t *testing.T) {
 	}
 }
 
+func TestTaskFormSaved(t *testing.T) {
+	task := Task{id: 42, description: "basic task", project: "task-gui", tags: []string{"rust", "cli"}}
+
+	edit := NewEditForm(task)
+	edit.index = 3
+	edit.project.SetValue("twkb")
+	edit.label.SetValue("go go rust")
+	saved, err := edit.saved()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !saved.edit || saved.index != 3 || strings.Join(saved.cmd, " ") != "task rc.confirmation=no 42 modify project:twkb +go -cli" {
+		t.Errorf("saved() = %+v, want the edit of index 3 with the modify command", saved)
+	}
+	if saved.task.project != "twkb" || !slices.Equal(saved.task.tags, []string{"go", "rust"}) || saved.task.description != "basic task" {
+		t.Errorf("saved().task = %+v, want the new project and tags", saved.task)
+	}
+
+	if _, err := NewEditForm(task).saved(); !errors.Is(err, errNothingToModify) {
+		t.Errorf("saved() of an unchanged form = %v, want %v", err, errNothingToModify)
+	}
+
+	add := newDefaultForm()
+	add.index = APPEND
+	add.description.SetValue("Call mom")
+	add.label.SetValue("home")
+	saved, err = add.saved()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saved.edit || strings.Join(saved.cmd, " ") != "task add Call mom +home" || saved.task.description != "Call mom" || saved.task.status != todo {
+		t.Errorf("saved() = %+v, want the add command and the new task", saved)
+	}
+}
+
 func TestBlockCmd(t *testing.T) {
 	validTests := []blockTest{
 		{
@@ -557,3 +707,129 @@ func TestUnblockCmd(t *testing.T) {
 		})
 	}
 }
+
+func TestExportCmd(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected string
+		filters  []string
+	}{
+		{"Export without filter", "task export", nil},
+		{"Export with empty filter", "task export", []string{"", " "}},
+		{"Export with context filter", "task (project:work) export", []string{"project:work"}},
+		{"Export with multiple filters", "task (+home or +errand) (due.before:eow) export", []string{"+home or +errand", "due.before:eow"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ExportCmd(tt.filters...)
+			if strings.Join(result, " ") != tt.expected {
+				t.Errorf("ExportCmd(%v) = %q, want %q", tt.filters, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestLaneCmd(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected string
+		groupBy  string
+		lane     string
+		task     Task
+		// tags are the tags of the moved task
+		tags []string
+	}{
+		{"Move to another project", "task rc.confirmation=no 4 modify project:work", "project", "work", Task{id: 4, project: "home"}, nil},
+		{"Move to the lane without project", "task rc.confirmation=no 4 modify project:", "project", noLane, Task{id: 4, project: "home"}, nil},
+		{"Move to another priority", "task rc.confirmation=no 4 modify priority:H", "priority", "H", Task{id: 4}, nil},
+		{"Move to a UDA lane", "task rc.confirmation=no 4 modify estimate:large", "estimate", "large", Task{id: 4}, nil},
+		{"Replace the first tag", "task rc.confirmation=no 4 modify -home +work", "tag", "work", Task{id: 4, tags: []string{"home", "errand"}}, []string{"work", "errand"}},
+		{"Move to the lane of a later tag", "task rc.confirmation=no 4 modify -home +errand", "tag", "errand", Task{id: 4, tags: []string{"home", "errand"}}, []string{"errand"}},
+		{"Add a tag", "task rc.confirmation=no 4 modify +work", "tag", "work", Task{id: 4}, []string{"work"}},
+		{"Remove the only tag", "task rc.confirmation=no 4 modify -home", "tag", noLane, Task{id: 4, tags: []string{"home"}}, nil},
+		{"Remove all tags", "task rc.confirmation=no 4 modify -home -errand", "tag", noLane, Task{id: 4, tags: []string{"home", "errand"}}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := LaneCmd(tt.task, tt.groupBy, tt.lane)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if strings.Join(result, " ") != tt.expected {
+				t.Errorf("LaneCmd(%v, %q, %q) = %q, want %q", tt.task, tt.groupBy, tt.lane, result, tt.expected)
+			}
+			moved := tt.task.MoveToLane(newFakeBackend(map[string]any{"id": 4}), tt.groupBy, tt.lane)
+			if !slices.Equal(moved.tags, tt.tags) {
+				t.Errorf("MoveToLane(%v, %q, %q).tags = %q, want %q", tt.task, tt.groupBy, tt.lane, moved.tags, tt.tags)
+			}
+		})
+	}
+
+	if _, err := LaneCmd(Task{id: 0}, "project", "work"); err == nil {
+		t.Error("expected an error for a task with ID 0")
+	}
+	if _, err := LaneCmd(Task{id: 4}, "tag", noLane); !errors.Is(err, errNothingToModify) {
+		t.Errorf("expected errNothingToModify, got %v", err)
+	}
+}
+
+func TestDiffTags(t *testing.T) {
+	tests := []struct {
+		name    string
+		current []string
+		wanted  []string
+		added   []string
+		removed []string
+	}{
+		{"No changes", []string{"rust", "cli"}, []string{"rust", "cli"}, nil, nil},
+		{"Order does not matter", []string{"rust", "cli"}, []string{"cli", "rust"}, nil, nil},
+		{"Add and remove", []string{"rust", "cli"}, []string{"rust", "go"}, []string{"go"}, []string{"cli"}},
+		{"Remove all", []string{"rust", "cli"}, nil, nil, []string{"rust", "cli"}},
+		{"Add to none", nil, []string{"go", "tui"}, []string{"go", "tui"}, nil},
+		{"Duplicates are added once", nil, []string{"go", "go"}, []string{"go"}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			added, removed := diffTags(tt.current, tt.wanted)
+			if !slices.Equal(added, tt.added) || !slices.Equal(removed, tt.removed) {
+				t.Errorf("diffTags(%v, %v) = %v, %v, want %v, %v", tt.current, tt.wanted, added, removed, tt.added, tt.removed)
+			}
+		})
+	}
+}
+
+func TestTagCmd(t *testing.T) {
+	task := Task{id: 7, tags: []string{"home", "errand"}}
+	tests := []struct {
+		name     string
+		expected string
+		wanted   []string
+	}{
+		{"Add a tag", "task rc.confirmation=no 7 modify +urgent", []string{"home", "errand", "urgent"}},
+		{"Remove a tag", "task rc.confirmation=no 7 modify -errand", []string{"home"}},
+		{"Toggle tags", "task rc.confirmation=no 7 modify +work -home", []string{"errand", "work"}},
+		{"Remove all tags", "task rc.confirmation=no 7 modify -home -errand", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := TagCmd(task, tt.wanted)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if strings.Join(result, " ") != tt.expected {
+				t.Errorf("TagCmd(%v, %v) = %q, want %q", task, tt.wanted, result, tt.expected)
+			}
+		})
+	}
+
+	if _, err := TagCmd(Task{id: 0}, []string{"work"}); err == nil {
+		t.Error("expected an error for a task with ID 0")
+	}
+	if _, err := TagCmd(task, []string{"errand", "home"}); !errors.Is(err, errNothingToModify) {
+		t.Errorf("expected errNothingToModify, got %v", err)
+	}
+}
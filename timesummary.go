@@ -0,0 +1,109 @@
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/DerTimonius/twkb/styles"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type timeRow struct {
+	name  string
+	total time.Duration
+	today time.Duration
+}
+
+// TimeSummary shows the time tracked with timewarrior per column and project.
+type TimeSummary struct {
+	columns  []timeRow
+	projects []timeRow
+}
+
+func NewTimeSummary(cols []*column) *TimeSummary {
+	now := time.Now()
+	s := TimeSummary{}
+	projects := map[string]*timeRow{}
+
+	for _, c := range cols {
+		// in swimlane mode every lane has its own columns of the same status
+		i := slices.IndexFunc(s.columns, func(r timeRow) bool { return r.name == c.status.title() })
+		if i == -1 {
+			s.columns = append(s.columns, timeRow{name: c.status.title()})
+			i = len(s.columns) - 1
+		}
+		row := &s.columns[i]
+		for _, task := range c.tasks() {
+			total := task.TrackedTotal(now)
+			today := task.TrackedToday(now)
+			row.total += total
+			row.today += today
+
+			if total == 0 {
+				continue
+			}
+			name := task.project
+			if name == "" {
+				name = "(none)"
+			}
+			if _, ok := projects[name]; !ok {
+				projects[name] = &timeRow{name: name}
+			}
+			projects[name].total += total
+			projects[name].today += today
+		}
+	}
+
+	for _, row := range projects {
+		s.projects = append(s.projects, *row)
+	}
+	slices.SortFunc(s.projects, func(a, b timeRow) int {
+		return cmp.Compare(b.total, a.total)
+	})
+
+	return &s
+}
+
+func (s TimeSummary) Init() tea.Cmd {
+	return nil
+}
+
+func (s TimeSummary) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, keys.Back), key.Matches(msg, keys.TimeSummary):
+			return s, closeView
+		case key.Matches(msg, keys.Quit):
+			return s, tea.Quit
+		}
+	}
+	return s, nil
+}
+
+func (s TimeSummary) View() string {
+	return styles.FormStyle.Render(
+		lipgloss.JoinVertical(
+			lipgloss.Left,
+			styles.TitleStyle.Render("Tracked time"),
+			renderTimeRows("Column", s.columns),
+			"",
+			renderTimeRows("Project", s.projects),
+		),
+	)
+}
+
+func renderTimeRows(heading string, rows []timeRow) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%-30s %10s %10s\n", heading, "Total", "Today")
+	b.WriteString(strings.Repeat("─", 52))
+	for _, r := range rows {
+		fmt.Fprintf(&b, "\n%-30s %10s %10s", r.name, formatDuration(r.total), formatDuration(r.today))
+	}
+	return b.String()
+}
@@ -0,0 +1,164 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+)
+
+const twTimeFormat = "20060102T150405Z"
+
+// interval is a single entry of `timew export`. An interval without an end is
+// the one that is currently being tracked.
+type interval struct {
+	start time.Time
+	end   time.Time
+	tags  []string
+}
+
+func TimewExportCmd() []string {
+	return []string{"timew", "export"}
+}
+
+func getFromTimew(tw Backend) ([]interval, error) {
+	output, err := tw.Run(TimewExportCmd())
+	if err != nil {
+		return nil, err
+	}
+	return parseTimewExport([]byte(output))
+}
+
+func parseTimewExport(data []byte) ([]interval, error) {
+	var result []struct {
+		Start string   `json:"start"`
+		End   string   `json:"end"`
+		Tags  []string `json:"tags"`
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, err
+	}
+
+	intervals := make([]interval, 0, len(result))
+	for _, r := range result {
+		start, err := time.Parse(twTimeFormat, r.Start)
+		if err != nil {
+			return nil, fmt.Errorf("invalid interval start %q: %v", r.Start, err)
+		}
+		i := interval{start: start, tags: r.Tags}
+		if r.End != "" {
+			end, err := time.Parse(twTimeFormat, r.End)
+			if err != nil {
+				return nil, fmt.Errorf("invalid interval end %q: %v", r.End, err)
+			}
+			i.end = end
+		}
+		intervals = append(intervals, i)
+	}
+	return intervals, nil
+}
+
+// matches reports whether the interval was recorded for the given task. The
+// on-modify.timewarrior hook tags every interval with the task description,
+// some setups add the UUID as well.
+func (i interval) matches(t *Task) bool {
+	if t.uuid != "" && slices.Contains(i.tags, t.uuid) {
+		return true
+	}
+	return t.description != "" && slices.Contains(i.tags, t.description)
+}
+
+// applyTracking sums up the intervals of the task. Closed intervals are added
+// to the tracked durations, an open interval marks the task as being tracked.
+func (t *Task) applyTracking(intervals []interval, now time.Time) {
+	t.tracked = 0
+	t.trackedToday = 0
+	t.trackingSince = time.Time{}
+	t.firstTracked = time.Time{}
+
+	for _, i := range intervals {
+		if !i.matches(t) {
+			continue
+		}
+		if t.firstTracked.IsZero() || i.start.Before(t.firstTracked) {
+			t.firstTracked = i.start
+		}
+		if i.end.IsZero() {
+			t.trackingSince = i.start
+			continue
+		}
+		t.tracked += i.end.Sub(i.start)
+		t.trackedToday += durationOnDay(i.start, i.end, now)
+	}
+}
+
+// startTracking and stopTracking mirror what the timewarrior hook does when a
+// task is started or stopped, so the board doesn't have to reload `timew export`.
+func (t *Task) startTracking(now time.Time) {
+	if !config.Timewarrior {
+		return
+	}
+	t.trackingSince = now
+}
+
+func (t *Task) stopTracking(now time.Time) {
+	if t.trackingSince.IsZero() {
+		return
+	}
+	t.tracked += now.Sub(t.trackingSince)
+	t.trackedToday += durationOnDay(t.trackingSince, now, now)
+	t.trackingSince = time.Time{}
+}
+
+func (t Task) TrackedTotal(now time.Time) time.Duration {
+	if t.trackingSince.IsZero() {
+		return t.tracked
+	}
+	return t.tracked + now.Sub(t.trackingSince)
+}
+
+func (t Task) TrackedToday(now time.Time) time.Duration {
+	if t.trackingSince.IsZero() {
+		return t.trackedToday
+	}
+	return t.trackedToday + durationOnDay(t.trackingSince, now, now)
+}
+
+// durationOnDay returns how much of the range from start to end lies on the
+// same day as day (in local time).
+func durationOnDay(start, end, day time.Time) time.Duration {
+	y, m, d := day.Local().Date()
+	dayStart := time.Date(y, m, d, 0, 0, 0, 0, time.Local)
+	dayEnd := dayStart.AddDate(0, 0, 1)
+
+	if start.Before(dayStart) {
+		start = dayStart
+	}
+	if end.After(dayEnd) {
+		end = dayEnd
+	}
+	if !end.After(start) {
+		return 0
+	}
+	return end.Sub(start)
+}
+
+// formatDuration renders durations like 2h05m or 12m, dropping the seconds.
+func formatDuration(d time.Duration) string {
+	d = d.Truncate(time.Minute)
+	h := int(d.Hours())
+	m := int(d.Minutes()) % 60
+	if h == 0 {
+		return fmt.Sprintf("%dm", m)
+	}
+	return fmt.Sprintf("%dh%02dm", h, m)
+}
+
+// formatTimer renders a running timer as hh:mm:ss.
+func formatTimer(d time.Duration) string {
+	d = d.Truncate(time.Second)
+	h := int(d.Hours())
+	m := int(d.Minutes()) % 60
+	s := int(d.Seconds()) % 60
+	return fmt.Sprintf("%02d:%02d:%02d", h, m, s)
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+type durationTest struct {
+	name     string
+	expected string
+	duration time.Duration
+}
+
+func TestParseTimewExport(t *testing.T) {
+	data := `[
+{"id":2,"start":"20240301T080000Z","end":"20240301T093000Z","tags":["write tests","twkb"]},
+{"id":1,"start":"20240301T100000Z","tags":["write tests","twkb"]}
+]`
+
+	intervals, err := parseTimewExport([]byte(data))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(intervals) != 2 {
+		t.Fatalf("Expected 2 intervals, got %d", len(intervals))
+	}
+	if d := intervals[0].end.Sub(intervals[0].start); d != 90*time.Minute {
+		t.Errorf("Expected the first interval to last 1h30m, got %v", d)
+	}
+	if !intervals[1].end.IsZero() {
+		t.Errorf("Expected the second interval to be open, got end %v", intervals[1].end)
+	}
+
+	if _, err := parseTimewExport([]byte(`[{"start":"yesterday"}]`)); err == nil {
+		t.Error("Expected an error for an invalid start, but got nil")
+	}
+}
+
+func TestApplyTracking(t *testing.T) {
+	now := time.Date(2024, 3, 2, 12, 0, 0, 0, time.Local)
+	intervals := []interval{
+		{start: now.Add(-26 * time.Hour), end: now.Add(-25 * time.Hour), tags: []string{"write tests"}},
+		{start: now.Add(-3 * time.Hour), end: now.Add(-2 * time.Hour), tags: []string{"write tests"}},
+		{start: now.Add(-90 * time.Minute), end: now.Add(-time.Hour), tags: []string{"another task"}},
+		{start: now.Add(-30 * time.Minute), tags: []string{"abc-123"}},
+	}
+
+	task := Task{description: "write tests", uuid: "abc-123"}
+	task.applyTracking(intervals, now)
+
+	if task.tracked != 2*time.Hour {
+		t.Errorf("Expected 2h of tracked time, got %v", task.tracked)
+	}
+	if task.trackedToday != time.Hour {
+		t.Errorf("Expected 1h of tracked time today, got %v", task.trackedToday)
+	}
+	if !task.trackingSince.Equal(now.Add(-30 * time.Minute)) {
+		t.Errorf("Expected the task to be tracked since 30m, got %v", task.trackingSince)
+	}
+	if total := task.TrackedTotal(now); total != 150*time.Minute {
+		t.Errorf("Expected 2h30m in total, got %v", total)
+	}
+
+	task.stopTracking(now)
+	if !task.trackingSince.IsZero() {
+		t.Error("Expected the task to not be tracked anymore")
+	}
+	if task.TrackedToday(now) != 90*time.Minute {
+		t.Errorf("Expected 1h30m of tracked time today, got %v", task.TrackedToday(now))
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	tests := []durationTest{
+		{"Less than a minute", "0m", 42 * time.Second},
+		{"Only minutes", "12m", 12*time.Minute + 30*time.Second},
+		{"Hours and minutes", "2h05m", 2*time.Hour + 5*time.Minute},
+		{"More than a day", "26h00m", 26 * time.Hour},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := formatDuration(tt.duration); result != tt.expected {
+				t.Errorf("formatDuration(%v) = %q, want %q", tt.duration, result, tt.expected)
+			}
+		})
+	}
+}
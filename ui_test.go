@@ -0,0 +1,219 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/exp/golden"
+	"github.com/charmbracelet/x/exp/teatest"
+	"github.com/muesli/termenv"
+)
+
+// uiTasks are the tasks the UI tests start with.
+func uiTasks() []map[string]any {
+	return []map[string]any{
+		{"description": "Write the release notes", "project": "work", "tags": []string{"docs"}},
+		{"description": "Fix the login bug", "project": "work.web", "tags": []string{"bug"}},
+		{"description": "Water the plants", "project": "home", "start": "20240301T080000Z"},
+		{"description": "Book the flights", "project": "home", "status": "completed", "end": "20240301T100000Z"},
+	}
+}
+
+// startUI runs the board on the fake backend in a terminal of 120x30.
+func startUI(t *testing.T, fake *fakeBackend) *teatest.TestModel {
+	t.Helper()
+	lipgloss.SetColorProfile(termenv.Ascii)
+	config = defaultConfig()
+	// the age of the cards changes while the tests run
+	config.Card.Fields = []string{"project", "tags", "urgency"}
+	t.Cleanup(func() { config = defaultConfig() })
+
+	b, err := loadBoard(fake, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return teatest.NewTestModel(t, NewApp(b), teatest.WithInitialTermSize(120, 30))
+}
+
+// waitFor waits until the screen shows the text.
+func waitFor(t *testing.T, tm *teatest.TestModel, text string) {
+	t.Helper()
+	teatest.WaitFor(t, tm.Output(), func(b []byte) bool {
+		return bytes.Contains(b, []byte(text))
+	}, teatest.WithDuration(3*time.Second))
+}
+
+// finalView quits the program and returns the view of the model it ended with.
+func finalView(t *testing.T, tm *teatest.TestModel) []byte {
+	t.Helper()
+	if err := tm.Quit(); err != nil {
+		t.Fatal(err)
+	}
+	return []byte(tm.FinalModel(t, teatest.WithFinalTimeout(3*time.Second)).View())
+}
+
+func requireCommands(t *testing.T, fake *fakeBackend, expected ...string) {
+	t.Helper()
+	if result := fake.commands(); strings.Join(result, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("expected the commands\n%s\ngot\n%s", strings.Join(expected, "\n"), strings.Join(result, "\n"))
+	}
+}
+
+func TestUIBoard(t *testing.T) {
+	fake := newFakeBackend(uiTasks()...)
+	tm := startUI(t, fake)
+	waitFor(t, tm, "Write the release notes")
+
+	golden.RequireEqual(t, finalView(t, tm))
+	requireCommands(t, fake)
+}
+
+func TestUICreate(t *testing.T) {
+	fake := newFakeBackend(uiTasks()...)
+	tm := startUI(t, fake)
+	waitFor(t, tm, "Write the release notes")
+
+	tm.Type("n")
+	// the form is higher than the terminal
+	waitFor(t, tm, "Priority:")
+	tm.Type("Call mom")
+	tm.Send(tea.KeyMsg{Type: tea.KeyTab})
+	tm.Type("home")
+	tm.Send(tea.KeyMsg{Type: tea.KeyEnter})
+	waitFor(t, tm, "Call mom")
+
+	golden.RequireEqual(t, finalView(t, tm))
+	requireCommands(t, fake, "task add Call mom project:home")
+}
+
+func TestUIMove(t *testing.T) {
+	fake := newFakeBackend(uiTasks()...)
+	tm := startUI(t, fake)
+	waitFor(t, tm, "Write the release notes")
+
+	// start the most urgent task, then finish the other one
+	tm.Send(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}})
+	waitFor(t, tm, "2 items")
+	tm.Send(tea.KeyMsg{Type: tea.KeyEnter})
+	waitFor(t, tm, "No items")
+
+	golden.RequireEqual(t, finalView(t, tm))
+	requireCommands(t, fake, "task 2 start", "task rc.confirmation=no 1 done")
+}
+
+func TestUIBlock(t *testing.T) {
+	fake := newFakeBackend(uiTasks()...)
+	tm := startUI(t, fake)
+	waitFor(t, tm, "Write the release notes")
+
+	tm.Type("b")
+	waitFor(t, tm, "blocks?")
+	tm.Send(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}})
+	tm.Send(tea.KeyMsg{Type: tea.KeyEnter})
+	// the card is cut off in the column
+	waitFor(t, tm, "Write the release notes [BLOCKE")
+
+	golden.RequireEqual(t, finalView(t, tm))
+	requireCommands(t, fake, "task 1 modify depends:2")
+}
+
+func TestUIDelete(t *testing.T) {
+	fake := newFakeBackend(uiTasks()...)
+	tm := startUI(t, fake)
+	waitFor(t, tm, "Write the release notes")
+
+	tm.Type("d")
+	waitFor(t, tm, "Are you sure you want to delete the task 'Fix the login bug'?")
+	tm.Type("y")
+	waitFor(t, tm, "│    1 item")
+
+	golden.RequireEqual(t, finalView(t, tm))
+	requireCommands(t, fake, "task rc.confirmation=no 2 delete")
+}
+
+func TestUIDeleteCancelled(t *testing.T) {
+	fake := newFakeBackend(uiTasks()...)
+	tm := startUI(t, fake)
+	waitFor(t, tm, "Write the release notes")
+
+	tm.Type("d")
+	waitFor(t, tm, "Are you sure")
+	tm.Type("n")
+	// the rows of the confirmation are drawn again
+	waitFor(t, tm, "│                                      │")
+
+	golden.RequireEqual(t, finalView(t, tm))
+	requireCommands(t, fake)
+}
+
+func TestUIOverlay(t *testing.T) {
+	fake := newFakeBackend(uiTasks()...)
+	tm := startUI(t, fake)
+	waitFor(t, tm, "Write the release notes")
+
+	tm.Type("d")
+	waitFor(t, tm, "Are you sure")
+
+	golden.RequireEqual(t, finalView(t, tm))
+}
+
+func TestUIOverlayResize(t *testing.T) {
+	fake := newFakeBackend(uiTasks()...)
+	tm := startUI(t, fake)
+	waitFor(t, tm, "Write the release notes")
+
+	tm.Type("#")
+	waitFor(t, tm, "Tags of")
+	tm.Send(tea.WindowSizeMsg{Width: 100, Height: 24})
+	waitFor(t, tm, "›")
+
+	golden.RequireEqual(t, finalView(t, tm))
+}
+
+func TestUIResize(t *testing.T) {
+	fake := newFakeBackend(uiTasks()...)
+	tm := startUI(t, fake)
+	waitFor(t, tm, "Write the release notes")
+
+	// only one column fits, the indicator lists the others
+	tm.Send(tea.WindowSizeMsg{Width: 60, Height: 20})
+	waitFor(t, tm, "›")
+
+	golden.RequireEqual(t, finalView(t, tm))
+}
+
+func TestUIDetail(t *testing.T) {
+	fake := newFakeBackend(uiTasks()...)
+	tm := startUI(t, fake)
+	waitFor(t, tm, "Write the release notes")
+
+	tm.Type("i")
+	waitFor(t, tm, "Urgency")
+
+	view := finalView(t, tm)
+	for _, text := range []string{"Fix the login bug", "work.web", "#bug", "00000000-0000-4000-8000-000000000002"} {
+		if !bytes.Contains(view, []byte(text)) {
+			t.Errorf("expected the details to show %q, got\n%s", text, view)
+		}
+	}
+	requireCommands(t, fake)
+}
+
+func TestUIDrag(t *testing.T) {
+	fake := newFakeBackend(uiTasks()...)
+	tm := startUI(t, fake)
+	waitFor(t, tm, "Write the release notes")
+
+	// drop the most urgent task on In Progress
+	tm.Send(tea.MouseMsg{X: 10, Y: 7, Button: tea.MouseButtonLeft, Action: tea.MouseActionPress})
+	tm.Send(tea.MouseMsg{X: 60, Y: 7, Button: tea.MouseButtonLeft, Action: tea.MouseActionMotion})
+	tm.Send(tea.MouseMsg{X: 60, Y: 10, Action: tea.MouseActionRelease})
+	waitFor(t, tm, "2 items")
+
+	golden.RequireEqual(t, finalView(t, tm))
+	requireCommands(t, fake, "task 2 start")
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"errors"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+var (
+	tagRe = regexp.MustCompile(`^[\pL_][\pL\pN_.\-]*$`)
+	// named periods and durations like 2w, 3 months or P1M are valid recurrences
+	namedPeriodRe = regexp.MustCompile(`^(daily|day|weekdays|weekly|biweekly|fortnight|sennight|monthly|bimonthly|quarterly|semiannual|annual|yearly|biannual|biyearly)$`)
+	periodRe      = regexp.MustCompile(`^\d*\s*(s|secs?|seconds?|mins?|minutes?|h|hrs?|hours?|d|days?|w|wks?|weeks?|mo|mths?|months?|q|qtrs?|quarters?|y|yrs?|years?)$`)
+	isoPeriodRe   = regexp.MustCompile(`^P(\d+[YMWD])*(T(\d+[HMS])+)?$`)
+)
+
+// virtualTags are reserved by taskwarrior and cannot be added to a task.
+var virtualTags = []string{
+	"ACTIVE", "ANNOTATED", "BLOCKED", "BLOCKING", "CHILD", "COMPLETED", "DELETED",
+	"DUE", "DUETODAY", "INSTANCE", "LATEST", "MONTH", "ORPHAN", "OVERDUE", "PARENT",
+	"PENDING", "PRIORITY", "PROJECT", "QUARTER", "READY", "SCHEDULED", "TAGGED",
+	"TEMPLATE", "TODAY", "TOMORROW", "UDA", "UNBLOCKED", "UNTIL", "WAITING", "WEEK",
+	"YEAR", "YESTERDAY",
+}
+
+func validateProject(project string) error {
+	if strings.ContainsAny(project, " \t") {
+		return errors.New("project cannot contain spaces")
+	}
+	if strings.HasPrefix(project, ".") || strings.HasSuffix(project, ".") {
+		return errors.New("project cannot start or end with a dot")
+	}
+	return nil
+}
+
+func validateTags(labels string) error {
+	for _, tag := range strings.Fields(labels) {
+		if slices.Contains(virtualTags, tag) {
+			return errors.New("'" + tag + "' is a reserved tag")
+		}
+		if !tagRe.MatchString(tag) {
+			return errors.New("'" + tag + "' is not a valid tag")
+		}
+	}
+	return nil
+}
+
+func validateRecur(recur string) error {
+	if recur == "" {
+		return nil
+	}
+	if namedPeriodRe.MatchString(recur) || periodRe.MatchString(recur) || (recur != "P" && isoPeriodRe.MatchString(recur)) {
+		return nil
+	}
+	return errors.New("not a valid period, e.g. weekly, 2w or monthly")
+}
+
+func validatePriority(priority string) error {
+	if priority == "" || slices.Contains([]string{"H", "M", "L"}, priority) {
+		return nil
+	}
+	return errors.New("priority has to be H, M or L")
+}
+
+// validateDate checks the result of resolving a date expression. An
+// expression that is still being resolved is not an error, but the form can't
+// be submitted until it is done.
+func validateDate(value string, d dateCalc) (bool, error) {
+	if value == "" {
+		return false, nil
+	}
+	if d.expr != value || (d.err == nil && d.date.IsZero()) {
+		return true, nil
+	}
+	return false, d.err
+}
+
+// validate returns the errors of the form, keyed by the name of the field.
+// The second return value reports whether a date is still being resolved.
+func (f TaskForm) validate() (map[string]error, bool) {
+	errs := map[string]error{}
+
+	if strings.TrimSpace(f.description.Value()) == "" {
+		errs["description"] = errors.New("description is required")
+	}
+	if err := validateProject(f.project.Value()); err != nil {
+		errs["project"] = err
+	}
+	if err := validateTags(f.label.Value()); err != nil {
+		errs["label"] = err
+	}
+	if err := validateRecur(f.recur.Value()); err != nil {
+		errs["recur"] = err
+	}
+
+	if err := validatePriority(f.priority.Value()); err != nil {
+		errs["priority"] = err
+	}
+
+	pending := false
+	for _, name := range dateFields {
+		p, err := validateDate(f.input(name).Value(), f.dates[name])
+		pending = pending || p
+		if err != nil {
+			errs[name] = err
+		}
+	}
+
+	if f.recur.Value() != "" && f.due.Value() == "" {
+		errs["recur"] = errors.New("a recurring task needs a due date")
+	}
+	due, until := f.dates["due"], f.dates["until"]
+	if errs["due"] == nil && errs["until"] == nil && !due.date.IsZero() && !until.date.IsZero() &&
+		due.expr == f.due.Value() && until.expr == f.until.Value() && !until.date.After(due.date) {
+		errs["until"] = errors.New("until has to be after the due date")
+	}
+
+	return errs, pending
+}
+
+// valid reports whether the form can be submitted.
+func (f TaskForm) valid() bool {
+	errs, pending := f.validate()
+	return len(errs) == 0 && !pending
+}
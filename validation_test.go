@@ -0,0 +1,110 @@
+package main
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+type validationTest struct {
+	name     string
+	field    string
+	expected string
+	form     *TaskForm
+	pending  bool
+}
+
+func resolvedForm(description, due, until string, dueDate, untilDate time.Time) *TaskForm {
+	f := newDefaultForm()
+	f.description.SetValue(description)
+	f.due.SetValue(due)
+	f.until.SetValue(until)
+	f.dates["due"] = dateCalc{field: "due", expr: due, date: dueDate}
+	f.dates["until"] = dateCalc{field: "until", expr: until, date: untilDate}
+	return f
+}
+
+func TestValidate(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.Local)
+
+	emptyForm := newDefaultForm()
+
+	projectForm := resolvedForm("valid", "", "", time.Time{}, time.Time{})
+	projectForm.project.SetValue("my project")
+
+	tagForm := resolvedForm("valid", "", "", time.Time{}, time.Time{})
+	tagForm.label.SetValue("go +tui")
+
+	virtualTagForm := resolvedForm("valid", "", "", time.Time{}, time.Time{})
+	virtualTagForm.label.SetValue("go ACTIVE")
+
+	recurForm := resolvedForm("valid", "eow", "", now, time.Time{})
+	recurForm.recur.SetValue("every tuesday")
+
+	recurWithoutDueForm := resolvedForm("valid", "", "", time.Time{}, time.Time{})
+	recurWithoutDueForm.recur.SetValue("weekly")
+
+	untilForm := resolvedForm("valid", "eow", "now", now, now.Add(-time.Hour))
+	untilForm.recur.SetValue("2w")
+
+	invalidDateForm := resolvedForm("valid", "someday-ish", "", time.Time{}, time.Time{})
+	invalidDateForm.dates["due"] = dateCalc{field: "due", expr: "someday-ish", err: errors.New("not a valid date")}
+
+	pendingForm := resolvedForm("valid", "eow", "", time.Time{}, time.Time{})
+
+	priorityForm := resolvedForm("valid", "", "", time.Time{}, time.Time{})
+	priorityForm.priority.SetValue("high")
+
+	tests := []validationTest{
+		{"Description is required", "description", "description is required", emptyForm, false},
+		{"Project without spaces", "project", "project cannot contain spaces", projectForm, false},
+		{"Invalid tag", "label", "'+tui' is not a valid tag", tagForm, false},
+		{"Virtual tag", "label", "'ACTIVE' is a reserved tag", virtualTagForm, false},
+		{"Invalid recurrence", "recur", "not a valid period, e.g. weekly, 2w or monthly", recurForm, false},
+		{"Recurrence without due date", "recur", "a recurring task needs a due date", recurWithoutDueForm, false},
+		{"Until before due", "until", "until has to be after the due date", untilForm, false},
+		{"Invalid date", "due", "not a valid date", invalidDateForm, false},
+		{"Invalid priority", "priority", "priority has to be H, M or L", priorityForm, false},
+		{"Date is still being resolved", "", "", pendingForm, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs, pending := tt.form.validate()
+			if pending != tt.pending {
+				t.Errorf("Expected pending to be %v, got %v", tt.pending, pending)
+			}
+			if tt.field == "" {
+				if len(errs) != 0 {
+					t.Errorf("Expected no errors, got %v", errs)
+				}
+				return
+			}
+			if errs[tt.field] == nil || errs[tt.field].Error() != tt.expected {
+				t.Errorf("Expected error %q for %s, got %v", tt.expected, tt.field, errs[tt.field])
+			}
+		})
+	}
+
+	validForm := resolvedForm("a valid task", "eow", "now+1yr", now, now.AddDate(1, 0, 0))
+	validForm.project.SetValue("work.web")
+	validForm.label.SetValue("go tui")
+	validForm.recur.SetValue("monthly")
+	if !validForm.valid() {
+		errs, _ := validForm.validate()
+		t.Errorf("Expected the form to be valid, got %v", errs)
+	}
+}
+
+func TestValidateRecur(t *testing.T) {
+	for _, recur := range []string{"", "daily", "weekdays", "2w", "3 months", "P1M", "PT12H", "yearly"} {
+		if err := validateRecur(recur); err != nil {
+			t.Errorf("validateRecur(%q) = %v, want nil", recur, err)
+		}
+	}
+	for _, recur := range []string{"every day", "P", "2x", "monthly-ish"} {
+		if err := validateRecur(recur); err == nil {
+			t.Errorf("validateRecur(%q) = nil, want an error", recur)
+		}
+	}
+}
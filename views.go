@@ -0,0 +1,104 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// noView goes back to the board as configured.
+const noView = "none"
+
+// namedView is a view of the config shown in the ViewPicker.
+type namedView struct {
+	name string
+	View
+}
+
+// implement the list.Item interface
+func (v namedView) FilterValue() string {
+	return v.name
+}
+
+func (v namedView) Title() string {
+	return v.name
+}
+
+func (v namedView) Description() string {
+	if v.name == noView {
+		return "show the board as configured"
+	}
+	var parts []string
+	if v.Filter != "" {
+		parts = append(parts, "filter: "+v.Filter)
+	}
+	if len(v.Columns) > 0 {
+		parts = append(parts, "columns: "+strings.Join(v.Columns, ", "))
+	}
+	if v.Sort != "" {
+		parts = append(parts, "sort: "+v.Sort)
+	}
+	if v.Swimlanes != "" {
+		parts = append(parts, "lanes: "+v.Swimlanes)
+	}
+	return strings.Join(parts, " • ")
+}
+
+// setView applies the named view, noView goes back to the settings of the
+// config. The tasks need to be reloaded afterwards.
+func (m *Board) setView(name string) error {
+	v, ok := config.Views[name]
+	if !ok && name != noView {
+		return fmt.Errorf("unknown view %q", name)
+	}
+
+	m.view, m.filter, m.sortBy = "", "", sortOrders[0]
+	m.statuses = columnStatuses(config.Columns)
+	m.groupBy = config.Swimlanes
+	if name == noView {
+		return nil
+	}
+	m.view, m.filter = name, v.Filter
+	if len(v.Columns) > 0 {
+		m.statuses = columnStatuses(v.Columns)
+	}
+	if v.Sort != "" {
+		m.sortBy = v.Sort
+	}
+	if v.Swimlanes != "" {
+		m.groupBy = v.Swimlanes
+	}
+	return nil
+}
+
+// viewSelectedMsg is the result of the view picker.
+type viewSelectedMsg struct {
+	name string
+}
+
+// ViewPicker lists the views of the config and switches to the selected one.
+type ViewPicker = Picker[namedView]
+
+func NewViewPicker(views map[string]View, active string, width, height int) *ViewPicker {
+	var names []string
+	for name := range views {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	items := []namedView{{name: noView}}
+	selected := 0
+	for _, name := range names {
+		if name == active {
+			selected = len(items)
+		}
+		items = append(items, namedView{name: name, View: views[name]})
+	}
+
+	p := newPicker("Switch view", items, pickerDelegate(), width, height)
+	p.list.Select(selected)
+	p.picked = func(v namedView) tea.Msg { return viewSelectedMsg{name: v.name} }
+	return p
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSetView(t *testing.T) {
+	config = defaultConfig()
+	config.Swimlanes = "tag"
+	config.Views = map[string]View{
+		"sprint": {Filter: "+sprint", Columns: []string{"todo", "doing"}, Sort: "due", Swimlanes: "project"},
+		"bugs":   {Filter: "+bug"},
+	}
+	t.Cleanup(func() { config = defaultConfig() })
+
+	b := NewBoard(newFakeBackend())
+	if err := b.setView("sprint"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if b.view != "sprint" || b.filter != "+sprint" || b.sortBy != "due" || b.groupBy != "project" {
+		t.Errorf("Expected the settings of the view, got view %q, filter %q, sort %q, lanes %q", b.view, b.filter, b.sortBy, b.groupBy)
+	}
+	if !slices.Equal(b.statuses, []status{todo, inProgress}) {
+		t.Errorf("Expected the columns of the view, got %v", b.statuses)
+	}
+
+	// empty fields keep the settings of the config
+	if err := b.setView("bugs"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if b.filter != "+bug" || b.sortBy != "urgency" || b.groupBy != "tag" || len(b.statuses) != 3 {
+		t.Errorf("Expected the config for empty fields, got filter %q, sort %q, lanes %q, columns %v", b.filter, b.sortBy, b.groupBy, b.statuses)
+	}
+
+	if err := b.setView(noView); err != nil || b.view != "" || b.filter != "" {
+		t.Errorf("Expected no view, got view %q, filter %q, error %v", b.view, b.filter, err)
+	}
+
+	// an unknown view keeps the current one
+	if err := b.setView("sprint"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := b.setView("backlog"); err == nil || b.view != "sprint" || b.filter != "+sprint" {
+		t.Errorf("Expected an error and the sprint view, got view %q, filter %q, error %v", b.view, b.filter, err)
+	}
+	_, cmd := b.Update(viewSelectedMsg{name: "backlog"})
+	if notice := noticeOf(cmd); notice != `unknown view "backlog"` || b.view != "sprint" {
+		t.Errorf("Expected a notice and the sprint view, got view %q, notice %q", b.view, notice)
+	}
+}
+
+func TestNamedViewDescription(t *testing.T) {
+	tests := []struct {
+		name     string
+		view     namedView
+		expected string
+	}{
+		{"No view", namedView{name: noView}, "show the board as configured"},
+		{"Filter only", namedView{name: "bugs", View: View{Filter: "+bug"}}, "filter: +bug"},
+		{
+			"All fields",
+			namedView{name: "sprint", View: View{Filter: "+sprint", Columns: []string{"todo", "doing"}, Sort: "due", Swimlanes: "project"}},
+			"filter: +sprint • columns: todo, doing • sort: due • lanes: project",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := tt.view.Description(); result != tt.expected {
+				t.Errorf("Description() = %q, want %q", result, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/DerTimonius/twkb/styles"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type wipState int
+
+const (
+	underLimit wipState = iota
+	atLimit
+	overLimit
+)
+
+// wipLimit returns the WIP limit of the column with the status, 0 means that
+// there is no limit.
+func wipLimit(s status) int {
+	return config.WIPLimits[s.String()]
+}
+
+func wipStateOf(count, limit int) wipState {
+	switch {
+	case limit <= 0 || count < limit:
+		return underLimit
+	case count == limit:
+		return atLimit
+	}
+	return overLimit
+}
+
+// count returns the number of tasks with the status, in swimlane mode the
+// limit applies to all lanes together.
+func (m *Board) count(s status) int {
+	var count int
+	for _, c := range m.allColumns() {
+		if c.status == s {
+			count += len(c.tasks())
+		}
+	}
+	return count
+}
+
+// columnTitle returns the title of the column and its style, columns with a
+// WIP limit show how many tasks they have and get a warning colour once they
+// reach the limit.
+func (m *Board) columnTitle(s status) (string, lipgloss.Style) {
+	limit := wipLimit(s)
+	if limit == 0 {
+		return s.title(), styles.DefaultListTitleStyle
+	}
+
+	count := m.count(s)
+	title := fmt.Sprintf("%s %d/%d", s.title(), count, limit)
+	switch wipStateOf(count, limit) {
+	case atLimit:
+		return title, styles.AtLimitTitleStyle
+	case overLimit:
+		return title, styles.OverLimitTitleStyle
+	}
+	return title, styles.DefaultListTitleStyle
+}
@@ -0,0 +1,65 @@
+package main
+
+import "testing"
+
+func TestWipStateOf(t *testing.T) {
+	tests := []struct {
+		name     string
+		count    int
+		limit    int
+		expected wipState
+	}{
+		{"No limit", 10, 0, underLimit},
+		{"Under the limit", 2, 3, underLimit},
+		{"At the limit", 3, 3, atLimit},
+		{"Over the limit", 4, 3, overLimit},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := wipStateOf(tt.count, tt.limit); result != tt.expected {
+				t.Errorf("wipStateOf(%d, %d) = %v, want %v", tt.count, tt.limit, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestColumnTitle(t *testing.T) {
+	config = defaultConfig()
+	config.WIPLimits = map[string]int{"doing": 2}
+	t.Cleanup(func() { config = defaultConfig() })
+
+	b := NewBoard(newFakeBackend())
+	b.build([]Task{
+		{description: "first", status: inProgress, project: "home"},
+		{description: "second", status: inProgress, project: "work"},
+		{description: "third", status: todo},
+	})
+
+	tests := []struct {
+		name     string
+		groupBy  string
+		status   status
+		expected string
+	}{
+		{"Column without limit", "", todo, "To Do"},
+		{"Column with limit", "", inProgress, "In Progress 2/2"},
+		{"Limit counts all lanes", "project", inProgress, "In Progress 2/2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b.groupBy = tt.groupBy
+			b.build(b.tasks())
+			if title, _ := b.columnTitle(tt.status); title != tt.expected {
+				t.Errorf("columnTitle(%v) = %q, want %q", tt.status, title, tt.expected)
+			}
+		})
+	}
+
+	// moving another task in goes over the limit
+	b.column(inProgress).list.InsertItem(0, Task{description: "fourth", status: inProgress})
+	if state := wipStateOf(b.count(inProgress), wipLimit(inProgress)); state != overLimit {
+		t.Errorf("state of %d/%d = %v, want %v", b.count(inProgress), wipLimit(inProgress), state, overLimit)
+	}
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// The columns and cards are marked in the rendered board with escape codes no
+// terminal uses. lipgloss counts them as zero width like all escape codes, so
+// they survive the layout, and scanZones removes them again before the board
+// is drawn.
+
+// noCard is the card of the zoneID of a whole column.
+const noCard = -1
+
+// zoneID names a column of a lane on the screen, or the card at the index of
+// its list.
+type zoneID struct {
+	lane   int
+	status status
+	card   int
+}
+
+// zone is the rectangle of the screen a column or card was drawn in.
+type zone struct {
+	x, y          int
+	width, height int
+}
+
+func (z zone) contains(x, y int) bool {
+	return x >= z.x && x < z.x+z.width && y >= z.y && y < z.y+z.height
+}
+
+var (
+	cardMarkerRe = regexp.MustCompile(`\x1b\[(\d+)z`)
+	zoneMarkerRe = regexp.MustCompile(`\x1b\[(\d+);(\d+)(?:;(\d+))?z`)
+)
+
+// markCard marks the rendered card at the index, the column qualifies it.
+func markCard(index int, card string) string {
+	marker := fmt.Sprintf("\x1b[%dz", index)
+	return marker + card + marker
+}
+
+// markColumn marks the rendered column of the lane and qualifies the cards in
+// it with the lane and status.
+func markColumn(lane int, s status, view string) string {
+	prefix := fmt.Sprintf("\x1b[%d;%d", lane, s)
+	view = cardMarkerRe.ReplaceAllString(view, prefix+";${1}z")
+	return prefix + "z" + view + prefix + "z"
+}
+
+// scanZones removes the markers from the view and returns where the marked
+// columns and cards are.
+func scanZones(view string) (string, map[zoneID]zone) {
+	zones := map[zoneID]zone{}
+	open := map[zoneID]bool{}
+	lines := strings.Split(view, "\n")
+	for y, line := range lines {
+		var b strings.Builder
+		last := 0
+		for _, loc := range zoneMarkerRe.FindAllStringSubmatchIndex(line, -1) {
+			b.WriteString(line[last:loc[0]])
+			last = loc[1]
+
+			lane, _ := strconv.Atoi(line[loc[2]:loc[3]])
+			s, _ := strconv.Atoi(line[loc[4]:loc[5]])
+			id := zoneID{lane: lane, status: status(s), card: noCard}
+			if loc[6] >= 0 {
+				id.card, _ = strconv.Atoi(line[loc[6]:loc[7]])
+			}
+
+			x := lipgloss.Width(b.String())
+			if open[id] {
+				z := zones[id]
+				z.width, z.height = x-z.x, y-z.y+1
+				zones[id] = z
+				delete(open, id)
+			} else {
+				zones[id] = zone{x: x, y: y}
+				open[id] = true
+			}
+		}
+		b.WriteString(line[last:])
+		lines[y] = b.String()
+	}
+	return strings.Join(lines, "\n"), zones
+}
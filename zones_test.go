@@ -0,0 +1,61 @@
+package main
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestScanZones(t *testing.T) {
+	first := markColumn(0, todo, lipgloss.NewStyle().Padding(1, 2).Render(markCard(0, "one")+"\n"+markCard(1, "two\nlines")))
+	second := markColumn(1, todo, markCard(0, "other"))
+	view, zones := scanZones(lipgloss.JoinVertical(lipgloss.Left, "header", lipgloss.JoinHorizontal(lipgloss.Top, first, second)))
+
+	if expected := "header        \n         other\n  one         \n  two         \n  lines       \n              "; view != expected {
+		t.Errorf("expected the markers to be removed, got\n%q", view)
+	}
+	tests := []struct {
+		name     string
+		id       zoneID
+		expected zone
+	}{
+		{"Column", zoneID{0, todo, noCard}, zone{0, 1, 9, 5}},
+		{"Card", zoneID{0, todo, 0}, zone{2, 2, 3, 1}},
+		{"Card with two lines", zoneID{0, todo, 1}, zone{2, 3, 5, 2}},
+		{"Card of another lane", zoneID{1, todo, 0}, zone{9, 1, 5, 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := zones[tt.id]; result != tt.expected {
+				t.Errorf("zone %v = %+v, want %+v", tt.id, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSelectedCard(t *testing.T) {
+	config = defaultConfig()
+	t.Cleanup(func() { config = defaultConfig() })
+
+	b := NewBoard(newFakeBackend())
+	b.build([]Task{
+		{description: "first", status: todo},
+		{description: "second", status: todo},
+	})
+	b.Update(tea.WindowSizeMsg{Width: 120, Height: 30})
+	b.focusedColumn().list.Select(1)
+	b.View()
+
+	z, ok := b.selectedCard()
+	if !ok {
+		t.Fatal("expected the selected card to be drawn")
+	}
+	if z.height != 3 || !z.contains(z.x, z.y) || z.contains(z.x, z.y+z.height) {
+		t.Errorf("expected a card of 3 lines, got %+v", z)
+	}
+	first := b.zones[zoneID{0, todo, 0}]
+	if z.y <= first.y {
+		t.Errorf("expected the second card below the first one at %+v, got %+v", first, z)
+	}
+}